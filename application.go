@@ -1,12 +1,10 @@
 package podio
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
-	"net/url"
 	"strconv"
 )
 
@@ -17,6 +15,8 @@ type Application struct {
 	OriginalID int `json:"original,omitempty"`
 	// "original_revision": The revision of the original app at the time of copy,
 	OriginalRevision int `json:"original_revision,omitempty"`
+	// "current_revision": The current revision of the app,
+	CurrentRevision int `json:"current_revision,omitempty"`
 	// "status": The status of the app, either "active", "inactive" or "deleted".
 	Status string `json:"status,omitempty"`
 	// "space_id": The id of the space on which the app is placed,
@@ -116,80 +116,221 @@ type AppIntegration struct {
 	NextRefreshOn string `json:"next_refresh_on,omitempty"`
 }
 
+// Field returns the field with the given external id or field id, or nil if
+// the app has none.
+func (a *Application) Field(key string) *Field {
+	for n := range a.Fields {
+		field := &a.Fields[n]
+		if field.ExternalID == key || strconv.Itoa(field.FieldID) == key {
+			return field
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) GetApplication(appID string) (*Application, error) {
+	return c.GetApplicationContext(context.Background(), appID)
+}
+
+func (c *Client) GetApplicationContext(ctx context.Context, appID string) (*Application, error) {
 	app := &Application{}
-	err := c.get(fmt.Sprintf("/app/%s", appID), app)
+	err := c.get(ctx, fmt.Sprintf("/app/%s", appID), app)
 	return app, err
 }
 
 func (c *Client) CreateApplication(spaceID string, params CreateApplicationParams) (*Application, error) {
+	return c.CreateApplicationContext(context.Background(), spaceID, params)
+}
+
+func (c *Client) CreateApplicationContext(ctx context.Context, spaceID string, params CreateApplicationParams) (*Application, error) {
 	var err error
 	params.SpaceID, err = strconv.Atoi(spaceID)
 	if err != nil {
 		return nil, fmt.Errorf("podio-go: invalid space id, must parse to int: %s", spaceID)
 	}
 
-	body := &bytes.Buffer{}
-	err = json.NewEncoder(body).Encode(params)
+	//This line defines a struct type and simultaneously creates an empty struct
+	data := &struct {
+		AppID int `json:"app_id"`
+	}{}
+	err = c.post(ctx, "/app", params, data)
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: could not encode params: %w", err)
+		return nil, fmt.Errorf("podio-go: failed to create application: %w", err)
 	}
 
-	resp, err := c.httpClient.Post("/app", "application/json", body)
+	return c.GetApplicationContext(ctx, strconv.Itoa(data.AppID))
+}
+
+func (c *Client) UpdateApplication(appID string, params CreateApplicationParams) (*Application, error) {
+	return c.UpdateApplicationContext(context.Background(), appID, params)
+}
+
+func (c *Client) UpdateApplicationContext(ctx context.Context, appID string, params CreateApplicationParams) (*Application, error) {
+	err := c.put(ctx, fmt.Sprintf("/app/%s", appID), params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to create application: %w", err)
+		return nil, fmt.Errorf("podio-go: failed to update application: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("podio-go: failed to create application: %s\nPayload: %s", resp.Status, string(output))
+	return c.GetApplicationContext(ctx, appID)
+}
+
+func (c *Client) DeleteApplication(appID string) error {
+	return c.DeleteApplicationContext(context.Background(), appID)
+}
+
+func (c *Client) DeleteApplicationContext(ctx context.Context, appID string) error {
+	return c.delete(ctx, fmt.Sprintf("/app/%s", appID))
+}
+
+func (c *Client) GetApplications(spaceID string) (*[]Application, error) {
+	return c.GetApplicationsContext(context.Background(), spaceID)
+}
+
+func (c *Client) GetApplicationsContext(ctx context.Context, spaceID string) (*[]Application, error) {
+	return collect(c.ListApplicationsContext(ctx, spaceID))
+}
+
+// ListApplications returns an iterator over the active apps of a space.
+func (c *Client) ListApplications(spaceID string) *Iterator[Application] {
+	return c.ListApplicationsContext(context.Background(), spaceID)
+}
+
+func (c *Client) ListApplicationsContext(ctx context.Context, spaceID string) *Iterator[Application] {
+	return NewIterator(ctx, 0, unpaginated[Application](c, fmt.Sprintf("/app/space/%s/?include_inactive=false", spaceID)))
+}
+
+// CopyApplication copies an app into another space, using Podio's install
+// endpoint. If Podio refuses to install the app, it is rebuilt from its
+// configuration and fields instead, and the returned app's OriginalID and
+// OriginalRevision are set to the app it was copied from.
+func (c *Client) CopyApplication(appID string, targetSpaceID string) (*Application, error) {
+	return c.CopyApplicationContext(context.Background(), appID, targetSpaceID)
+}
+
+func (c *Client) CopyApplicationContext(ctx context.Context, appID string, targetSpaceID string) (*Application, error) {
+	spaceID, err := strconv.Atoi(targetSpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: invalid space id, must parse to int: %s", targetSpaceID)
 	}
 
-	//This line defines a struct type and simultaneously creates an empty struct
+	params := struct {
+		SpaceID int `json:"space_id"`
+	}{spaceID}
 	data := &struct {
 		AppID int `json:"app_id"`
 	}{}
-	err = json.NewDecoder(resp.Body).Decode(data)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to decode application response: %w", err)
+	err = c.post(ctx, fmt.Sprintf("/app/%s/install", appID), params, data)
+	if err == nil {
+		return c.GetApplicationContext(ctx, strconv.Itoa(data.AppID))
 	}
 
-	return c.GetApplication(strconv.Itoa(data.AppID))
-}
+	var apiErr *APIError
+	refused := errors.Is(err, ErrForbidden) || errors.Is(err, ErrNotFound) ||
+		(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest)
+	if !refused {
+		return nil, fmt.Errorf("podio-go: failed to copy application: %w", err)
+	}
 
-func (c *Client) UpdateApplication(appID string, params CreateApplicationParams) (*Application, error) {
-	body := &bytes.Buffer{}
-	json.NewEncoder(body).Encode(params)
+	source, err := c.GetApplicationContext(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to copy application: %w", err)
+	}
 
-	url, err := url.Parse(fmt.Sprintf("/app/%s", appID))
+	config, fields := portableApp(source)
+	app, err := c.CreateApplicationContext(ctx, targetSpaceID, CreateApplicationParams{Config: config, Fields: fields})
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to generate url: %w", err)
+		return nil, fmt.Errorf("podio-go: failed to copy application: %w", err)
 	}
 
-	req := &http.Request{
-		Method: "PUT",
-		URL:    url,
-		Body:   ioutil.NopCloser(body),
+	// Point fields referencing the source app at the copy.
+	for _, field := range app.Fields {
+		if field.Type != FieldTypeApp {
+			continue
+		}
+
+		selfReference := false
+		field.Config.Settings = mapReferencedApps(field.Config.Settings, func(entry map[string]interface{}) bool {
+			if id, _ := entry["app_id"].(float64); int(id) == source.AppID {
+				entry["app_id"] = app.AppID
+				selfReference = true
+			}
+			return true
+		})
+
+		if selfReference {
+			_, err := c.UpdateFieldContext(ctx, strconv.Itoa(app.AppID), strconv.Itoa(field.FieldID), field.Config)
+			if err != nil {
+				return nil, fmt.Errorf("podio-go: failed to copy application: %w", err)
+			}
+		}
 	}
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to update application: %w", err)
+
+	if len(app.Fields) > 0 {
+		app, err = c.GetApplicationContext(ctx, strconv.Itoa(app.AppID))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("podio-go: failed to update application: %s\nPayload: %s", resp.Status, string(output))
+	app.OriginalID = source.AppID
+	app.OriginalRevision = source.CurrentRevision
+	return app, nil
+}
+
+// portableApp returns the configuration and active fields of app without
+// what belongs to the app itself, so they can be used to create an app in
+// any space or organization: field ids and statuses, the ids and statuses
+// of category options, deleted options, and the responsible users of tasks.
+func portableApp(app *Application) (AppConfig, []Field) {
+	config := app.Config
+	config.Tasks = nil
+	for _, task := range app.Config.Tasks {
+		config.Tasks = append(config.Tasks, AppTask{Text: task.Text})
 	}
 
-	return c.GetApplication(appID)
+	fields := []Field{}
+	for _, field := range activeFields(app) {
+		settings := generic(field.Config.Settings)
+		if decoded, ok := settings.(map[string]interface{}); ok {
+			if options, ok := decoded["options"].([]interface{}); ok {
+				decoded["options"] = portableOptions(options)
+			}
+		}
+
+		fieldConfig := field.Config
+		fieldConfig.Settings = settings
+		fields = append(fields, Field{ExternalID: field.ExternalID, Type: field.Type, Config: fieldConfig})
+	}
+
+	return config, fields
 }
 
-func (c *Client) DeleteApplication(appID string) error {
-	return c.delete(fmt.Sprintf("/app/%s", appID))
+// taskUserIDs reduces the responsible users of tasks to their user ids.
+func taskUserIDs(tasks []AppTask) []AppTask {
+	var reduced []AppTask
+	for _, task := range tasks {
+		responsible := make([]User, 0, len(task.Responsible))
+		for _, user := range task.Responsible {
+			responsible = append(responsible, User{UserID: user.UserID})
+		}
+		reduced = append(reduced, AppTask{Text: task.Text, Responsible: responsible})
+	}
+
+	return reduced
 }
 
-func (c *Client) GetApplications(spaceID string) (*[]Application, error) {
-	apps := &[]Application{}
-	err := c.get(fmt.Sprintf("/app/space/%s/?include_inactive=false", spaceID), apps)
-	return apps, err
+// portableOptions strips the ids and statuses of category options, leaving
+// out deleted ones.
+func portableOptions(options []interface{}) []interface{} {
+	copied := []interface{}{}
+	for _, option := range withoutDeleted(options) {
+		if option, ok := option.(map[string]interface{}); ok {
+			delete(option, "id")
+			delete(option, "status")
+		}
+		copied = append(copied, option)
+	}
+
+	return copied
 }
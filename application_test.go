@@ -0,0 +1,133 @@
+package podio_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+// copySource adds an app to copy, with a field referring to the app itself,
+// and a space to copy it to.
+func copySource(srv *podiotest.Server) (*podio.Application, *podio.Space) {
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	target := srv.AddSpace(podio.Space{Name: "Archive"})
+
+	const sourceID = 5000
+	source := srv.AddApplication(podio.Application{
+		AppID:           sourceID,
+		SpaceID:         space.ID,
+		CurrentRevision: 3,
+		Config:          podio.AppConfig{Name: "Deals"},
+		Fields: []podio.Field{
+			{ExternalID: "title", Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Title"}},
+			{ExternalID: "parent", Type: podio.FieldTypeApp, Config: podio.FieldConfig{
+				Label: "Parent",
+				Settings: map[string]interface{}{"referenced_apps": []interface{}{
+					map[string]interface{}{"app_id": sourceID},
+				}},
+			}},
+		},
+	})
+
+	return source, target
+}
+
+// created reports whether an app was created with POST /app.
+func created(srv *podiotest.Server) bool {
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path == "/app" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCopyApplicationInstalls(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	source, target := copySource(srv)
+
+	app, err := srv.NewClient().CopyApplication(strconv.Itoa(source.AppID), strconv.Itoa(target.ID))
+	if err != nil {
+		t.Fatalf("CopyApplication: %v", err)
+	}
+
+	if app.AppID == source.AppID || app.SpaceID != target.ID {
+		t.Errorf("copy is app %d in space %d, want a new app in space %d", app.AppID, app.SpaceID, target.ID)
+	}
+	if app.OriginalID != source.AppID || app.OriginalRevision != 3 {
+		t.Errorf("copy is of app %d revision %d, want %d revision 3", app.OriginalID, app.OriginalRevision, source.AppID)
+	}
+	if len(app.Fields) != 2 || app.Field("title") == nil || app.Field("title").FieldID == source.Field("title").FieldID {
+		t.Errorf("copied fields = %+v, want both fields with new ids", app.Fields)
+	}
+	if created(srv) {
+		t.Error("the app was rebuilt although it could be installed")
+	}
+}
+
+func TestCopyApplicationRebuildsWhenRefused(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"forbidden", http.StatusForbidden},
+		{"not found", http.StatusNotFound},
+		{"bad request", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := podiotest.NewServer()
+			defer srv.Close()
+
+			source, target := copySource(srv)
+			client := srv.NewClient()
+			srv.Inject(podiotest.Fault{
+				Method:      http.MethodPost,
+				Path:        "/app/" + strconv.Itoa(source.AppID) + "/install",
+				Status:      tt.status,
+				Code:        "refused",
+				Description: "The app can't be installed",
+			})
+
+			app, err := client.CopyApplication(strconv.Itoa(source.AppID), strconv.Itoa(target.ID))
+			if err != nil {
+				t.Fatalf("CopyApplication: %v", err)
+			}
+
+			if !created(srv) {
+				t.Fatal("the app wasn't rebuilt")
+			}
+			if app.SpaceID != target.ID || app.Config.Name != "Deals" {
+				t.Errorf("copy = %+v, want Deals in space %d", app, target.ID)
+			}
+			if app.OriginalID != source.AppID || app.OriginalRevision != 3 {
+				t.Errorf("copy is of app %d revision %d, want %d revision 3", app.OriginalID, app.OriginalRevision, source.AppID)
+			}
+			if ids := referencedApps(app.Field("parent")); len(ids) != 1 || ids[0] != app.AppID {
+				t.Errorf("copied self reference refers to %v, want [%d]", ids, app.AppID)
+			}
+		})
+	}
+}
+
+func TestCopyApplicationInstallFails(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	source, target := copySource(srv)
+	client := srv.NewClient()
+	srv.Inject(podiotest.InternalError(http.MethodPost, "/app/"+strconv.Itoa(source.AppID)+"/install"))
+
+	if app, err := client.CopyApplication(strconv.Itoa(source.AppID), strconv.Itoa(target.ID)); err == nil {
+		t.Fatalf("CopyApplication = %+v, want the install error", app)
+	}
+	if created(srv) {
+		t.Error("the app was rebuilt after an error that isn't a refusal")
+	}
+}
@@ -0,0 +1,130 @@
+package podio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AuthCodeURL returns the URL to send a user to so they can grant this client
+// access to their Podio account. Podio redirects back to redirectURI with a
+// code and the given state, which should be checked before calling ExchangeCode.
+func (c *Client) AuthCodeURL(redirectURI, state string) string {
+	query := url.Values{}
+	query.Set("client_id", c.options.ApiKey)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+
+	authorizeURL := *c.authURL
+	authorizeURL.Path = "/oauth/authorize"
+	authorizeURL.RawQuery = query.Encode()
+
+	return authorizeURL.String()
+}
+
+// ExchangeCode exchanges an authorization code for a token, and returns a new
+// client authenticated as the user who granted it. The redirectURI must match
+// the one given to AuthCodeURL.
+func (c *Client) ExchangeCode(code, redirectURI string) (*Client, error) {
+	return c.ExchangeCodeContext(context.Background(), code, redirectURI)
+}
+
+// ExchangeCodeContext is ExchangeCode with a context.
+func (c *Client) ExchangeCodeContext(ctx context.Context, code, redirectURI string) (*Client, error) {
+	oauth, err := c.doOAuthGrant(ctx, oAuth2Request{
+		Code:         code,
+		RedirectURI:  redirectURI,
+		ClientID:     c.options.ApiKey,
+		ClientSecret: c.options.ApiSecret,
+		GrantType:    "authorization_code",
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The user's token must not end up in the app's token store.
+	options := c.options
+	options.TokenStore = nil
+
+	user := NewClient(options)
+	if err := user.auth.setToken(newToken(oauth)); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// NewOAuthState returns a random value suitable for the state parameter of AuthCodeURL.
+func NewOAuthState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("podio-go: failed to generate OAuth state: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// AuthCodeHandler is an http.Handler to mount at the redirect URI of the
+// authorization code flow. It checks the returned state, exchanges the code
+// and hands the resulting user client to OnSuccess.
+type AuthCodeHandler struct {
+	// Client is the app client used to exchange the code.
+	Client *Client
+	// RedirectURI must match the one given to AuthCodeURL.
+	RedirectURI string
+	// CheckState reports whether state was issued for this request, e.g. by
+	// comparing it to a cookie set before redirecting to AuthCodeURL.
+	CheckState func(r *http.Request, state string) bool
+	// OnSuccess is called with a client authenticated as the user. It is
+	// required: without it, requests fail before the code is exchanged.
+	OnSuccess func(w http.ResponseWriter, r *http.Request, user *Client)
+	// OnError is called when the user denied access or the exchange failed.
+	// Defaults to writing a plain error response.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func (h *AuthCodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.OnSuccess == nil {
+		h.fail(w, r, http.StatusInternalServerError, fmt.Errorf("podio-go: AuthCodeHandler.OnSuccess is not set"))
+		return
+	}
+
+	query := r.URL.Query()
+
+	if reason := query.Get("error"); reason != "" {
+		h.fail(w, r, http.StatusForbidden, fmt.Errorf("podio-go: authorization failed: %s: %s", reason, query.Get("error_description")))
+		return
+	}
+
+	if h.CheckState == nil || !h.CheckState(r, query.Get("state")) {
+		h.fail(w, r, http.StatusBadRequest, fmt.Errorf("podio-go: authorization failed: invalid state"))
+		return
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		h.fail(w, r, http.StatusBadRequest, fmt.Errorf("podio-go: authorization failed: missing code"))
+		return
+	}
+
+	user, err := h.Client.ExchangeCodeContext(r.Context(), code, h.RedirectURI)
+	if err != nil {
+		h.fail(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	h.OnSuccess(w, r, user)
+}
+
+func (h *AuthCodeHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(w, r, err)
+		return
+	}
+
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,113 @@
+package podio_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+const redirectURI = "https://example.com/callback"
+
+func callback(code, state string) *http.Request {
+	query := url.Values{"code": {code}, "state": {state}}
+	return httptest.NewRequest(http.MethodGet, "/callback?"+query.Encode(), nil)
+}
+
+func authCodeHandler(srv *podiotest.Server, onSuccess func(w http.ResponseWriter, r *http.Request, user *podio.Client)) *podio.AuthCodeHandler {
+	client, err := podio.NewClientWithOptions(
+		podio.WithCredentials(podiotest.ClientID, podiotest.ClientSecret),
+		podio.WithAPIURL(srv.URL),
+		podio.WithAuthURL(srv.URL),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return &podio.AuthCodeHandler{
+		Client:      client,
+		RedirectURI: redirectURI,
+		CheckState: func(r *http.Request, state string) bool {
+			return state == "expected"
+		},
+		OnSuccess: onSuccess,
+	}
+}
+
+func TestAuthCodeHandler(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	var user *podio.Client
+	handler := authCodeHandler(srv, func(w http.ResponseWriter, r *http.Request, client *podio.Client) {
+		user = client
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, callback(srv.AddAuthCode(), "expected"))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body)
+	}
+	if user == nil {
+		t.Fatal("OnSuccess was not called")
+	}
+	if _, err := user.GetOrganizations(); err != nil {
+		t.Errorf("user client: %v", err)
+	}
+}
+
+func TestAuthCodeHandlerFailures(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"denied", httptest.NewRequest(http.MethodGet, "/callback?error=access_denied&state=expected", nil), http.StatusForbidden},
+		{"invalid state", callback(srv.AddAuthCode(), "forged"), http.StatusBadRequest},
+		{"missing code", callback("", "expected"), http.StatusBadRequest},
+		{"invalid code", callback("unknown", "expected"), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := authCodeHandler(srv, func(w http.ResponseWriter, r *http.Request, user *podio.Client) {
+				t.Error("OnSuccess was called")
+			})
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthCodeHandlerWithoutOnSuccess(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	code := srv.AddAuthCode()
+	handler := authCodeHandler(srv, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, callback(code, "expected"))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	for _, r := range srv.Requests() {
+		if r.Path == "/oauth/token" {
+			t.Error("the code was exchanged without OnSuccess")
+		}
+	}
+}
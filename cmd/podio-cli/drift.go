@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kayteh/podio-go"
+)
+
+// runDrift compares every app spec in a directory with the live apps. It
+// exits with 1 if any app drifted from its spec, and 2 if a spec couldn't
+// be checked.
+func runDrift(client *podio.Client, args []string) {
+	if len(args) < 1 {
+		fmt.Println("Usage: podio-cli drift <spec-dir>")
+		os.Exit(2)
+	}
+
+	var paths []string
+	for _, pattern := range []string{"*.json", "*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(args[0], pattern))
+		if err != nil {
+			fmt.Println("Failed to list specs:", err)
+			os.Exit(2)
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	if len(paths) == 0 {
+		fmt.Println("No specs found in", args[0])
+		os.Exit(2)
+	}
+
+	drifted, failed := 0, 0
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+
+		spec, err := podio.LoadAppSpec(path)
+		if err != nil {
+			fmt.Printf("%s: %s\n", name, err)
+			failed++
+			continue
+		}
+
+		plan, err := client.PlanSchema(spec)
+		if err != nil {
+			fmt.Printf("%s: app %d: %s\n", name, spec.AppID, err)
+			failed++
+			continue
+		}
+
+		if plan.Empty() {
+			fmt.Printf("%s: app %d matches its spec\n", name, spec.AppID)
+			continue
+		}
+
+		drifted++
+		fmt.Printf("%s: app %d drifted from its spec:\n", name, spec.AppID)
+		for _, line := range strings.Split(strings.TrimRight(plan.String(), "\n"), "\n") {
+			fmt.Println("  " + line)
+		}
+	}
+
+	fmt.Printf("%d apps checked, %d drifted, %d failed\n", len(paths), drifted, failed)
+
+	if failed > 0 {
+		os.Exit(2)
+	}
+	if drifted > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kayteh/podio-go"
+)
+
+const (
+	columnItemID     = "item_id"
+	columnExternalID = "external_id"
+)
+
+// runItems runs the items export and import commands.
+func runItems(client *podio.Client, args []string) {
+	if len(args) < 2 {
+		fmt.Println("Usage: podio-cli items export <appID> [flags] | items import <appID> <file> [flags]")
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "export":
+		exportItems(client, args[1], args[2:])
+	case "import":
+		if len(args) < 3 {
+			fmt.Println("Usage: podio-cli items import <appID> <file> [flags]")
+			os.Exit(2)
+		}
+		importItems(client, args[1], args[2], args[3:])
+	default:
+		fmt.Println("Unknown items command:", args[0])
+		os.Exit(2)
+	}
+}
+
+func exportItems(client *podio.Client, appID string, args []string) {
+	flags := flag.NewFlagSet("items export", flag.ExitOnError)
+	format := flags.String("format", "csv", "output format, csv or jsonl")
+	columns := flags.String("columns", "external_id", "name columns by field label or external_id")
+	out := flags.String("out", "", "file to write (defaults to stdout)")
+	flags.Parse(args)
+
+	app, err := client.GetApplication(appID)
+	if err != nil {
+		fmt.Println("Failed to get app:", err)
+		os.Exit(1)
+	}
+
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if *out != "" {
+		file, err = os.Create(*out)
+		if err != nil {
+			fmt.Println("Failed to create output:", err)
+			os.Exit(1)
+		}
+		w = file
+	}
+
+	header := []string{columnItemID, columnExternalID}
+	for _, field := range app.Fields {
+		if field.Status == "deleted" {
+			continue
+		}
+		if *columns == "label" {
+			header = append(header, field.Config.Label)
+		} else {
+			header = append(header, field.ExternalID)
+		}
+	}
+
+	var writeRow func(row []string) error
+	flush := func() error { return nil }
+	switch *format {
+	case "csv":
+		csvWriter := csv.NewWriter(w)
+		writeRow = csvWriter.Write
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+		if err := writeRow(header); err != nil {
+			fmt.Println("Failed to write header:", err)
+			os.Exit(1)
+		}
+	case "jsonl":
+		encoder := json.NewEncoder(w)
+		writeRow = func(row []string) error {
+			object := make(map[string]string, len(row))
+			for i, column := range row {
+				if column != "" {
+					object[header[i]] = column
+				}
+			}
+			return encoder.Encode(object)
+		}
+	default:
+		fmt.Println("Unknown format:", *format)
+		os.Exit(2)
+	}
+
+	it := client.IterateItems(appID, nil)
+	defer it.Close()
+
+	for it.Next() {
+		item := it.Value()
+		row := []string{strconv.Itoa(item.ItemID), item.ExternalID}
+
+		for _, field := range app.Fields {
+			if field.Status == "deleted" {
+				continue
+			}
+
+			column := ""
+			if itemField := item.Field(strconv.Itoa(field.FieldID)); itemField != nil {
+				values, err := itemField.DecodeValues()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "item %d: %s\n", item.ItemID, err)
+				}
+				column = formatValues(values)
+			}
+			row = append(row, column)
+		}
+
+		if err := writeRow(row); err != nil {
+			fmt.Println("Failed to write item:", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		fmt.Println("Failed to list items:", err)
+		os.Exit(1)
+	}
+
+	if err := flush(); err != nil {
+		fmt.Println("Failed to write items:", err)
+		os.Exit(1)
+	}
+
+	if file != nil {
+		if err := file.Close(); err != nil {
+			fmt.Println("Failed to close output:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// importRow is a row to import, with columns keyed by header.
+type importRow struct {
+	line    int
+	columns map[string]string
+}
+
+// importResult is a line of the import report.
+type importResult struct {
+	line   int
+	action string
+	itemID int
+	err    error
+}
+
+func importItems(client *podio.Client, appID, path string, args []string) {
+	flags := flag.NewFlagSet("items import", flag.ExitOnError)
+	format := flags.String("format", "", "input format, csv or jsonl (defaults to the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate every row without creating or updating items")
+	upsert := flags.Bool("upsert", false, "update the item with the row's external_id if it exists, instead of creating a new one")
+	reportPath := flags.String("report", "", "write a CSV report of every row to this file")
+	flags.Parse(args)
+
+	if *format == "" {
+		*format = "csv"
+		if strings.HasSuffix(path, ".jsonl") || strings.HasSuffix(path, ".ndjson") {
+			*format = "jsonl"
+		}
+	}
+
+	app, err := client.GetApplication(appID)
+	if err != nil {
+		fmt.Println("Failed to get app:", err)
+		os.Exit(1)
+	}
+
+	rows, err := readRows(path, *format)
+	if err != nil {
+		fmt.Println("Failed to read input:", err)
+		os.Exit(1)
+	}
+
+	var results []importResult
+	failed := 0
+
+	for _, row := range rows {
+		result := importItem(client, app, row, *upsert, *dryRun)
+		if result.err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", result.line, result.err)
+		}
+		results = append(results, result)
+	}
+
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, results); err != nil {
+			fmt.Println("Failed to write report:", err)
+		}
+	}
+
+	verb := "Imported"
+	if *dryRun {
+		verb = "Validated"
+	}
+	fmt.Printf("%s %d rows, %d failed\n", verb, len(rows)-failed, failed)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func importItem(client *podio.Client, app *podio.Application, row importRow, upsert, dryRun bool) importResult {
+	result := importResult{line: row.line}
+
+	fields, err := rowFields(app, row)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	params := podio.CreateItemParams{
+		ExternalID: row.columns[columnExternalID],
+		Fields:     fields,
+	}
+
+	result.action = "create"
+	if upsert {
+		if params.ExternalID == "" {
+			result.err = fmt.Errorf("upsert needs an %s column", columnExternalID)
+			return result
+		}
+
+		existing, err := client.GetItemByExternalID(strconv.Itoa(app.AppID), params.ExternalID)
+		if err == nil {
+			result.action = "update"
+			result.itemID = existing.ItemID
+		} else if !errors.Is(err, podio.ErrNotFound) {
+			result.err = err
+			return result
+		}
+	}
+
+	if dryRun {
+		return result
+	}
+
+	var item *podio.Item
+	if result.action == "update" {
+		item, err = client.UpdateItem(strconv.Itoa(result.itemID), params)
+	} else {
+		item, err = client.CreateItem(strconv.Itoa(app.AppID), params)
+	}
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	result.itemID = item.ItemID
+	return result
+}
+
+// rowFields maps the columns of a row to fields by external id or label.
+func rowFields(app *podio.Application, row importRow) (podio.ItemFields, error) {
+	fields := podio.ItemFields{}
+	var problems []string
+
+	for column, value := range row.columns {
+		if column == columnItemID || column == columnExternalID {
+			continue
+		}
+
+		field := fieldForColumn(app, column)
+		if field == nil {
+			problems = append(problems, fmt.Sprintf("no field matches column %q", column))
+			continue
+		}
+
+		if field.Type == podio.FieldTypeCalculation {
+			continue
+		}
+
+		values, err := parseValues(field, value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("column %q: %s", column, err))
+			continue
+		}
+
+		if values != nil {
+			fields.Set(*field, values)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+
+	return fields, nil
+}
+
+func fieldForColumn(app *podio.Application, column string) *podio.Field {
+	if field := app.Field(column); field != nil {
+		return field
+	}
+
+	for n := range app.Fields {
+		if strings.EqualFold(app.Fields[n].Config.Label, column) {
+			return &app.Fields[n]
+		}
+	}
+
+	return nil
+}
+
+func readRows(path, format string) ([]importRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows []importRow
+
+	switch format {
+	case "csv":
+		reader := csv.NewReader(file)
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+
+		header := records[0]
+		for n, record := range records[1:] {
+			columns := map[string]string{}
+			for i, value := range record {
+				if i < len(header) {
+					columns[header[i]] = value
+				}
+			}
+			rows = append(rows, importRow{line: n + 2, columns: columns})
+		}
+	case "jsonl":
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			if strings.TrimSpace(scanner.Text()) == "" {
+				continue
+			}
+
+			// Keep numbers as written, as floats would turn large ids into
+			// exponents.
+			object := map[string]json.RawMessage{}
+			if err := json.Unmarshal(scanner.Bytes(), &object); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			columns := map[string]string{}
+			for key, raw := range object {
+				var text string
+				switch {
+				case string(raw) == "null":
+				case json.Unmarshal(raw, &text) == nil:
+					columns[key] = text
+				default:
+					columns[key] = string(raw)
+				}
+			}
+			rows = append(rows, importRow{line: line, columns: columns})
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	return rows, nil
+}
+
+func writeReport(path string, results []importResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write([]string{"line", "status", "action", columnItemID, "error"})
+
+	for _, result := range results {
+		status, message, itemID := "ok", "", ""
+		if result.err != nil {
+			status, message = "error", result.err.Error()
+		}
+		if result.itemID != 0 {
+			itemID = strconv.Itoa(result.itemID)
+		}
+		w.Write([]string{strconv.Itoa(result.line), status, result.action, itemID, message})
+	}
+
+	w.Flush()
+	return w.Error()
+}
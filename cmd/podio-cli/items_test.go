@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadRowsCSV(t *testing.T) {
+	path := writeFile(t, "items.csv", "item_id,external_id,customer,stage\n,deal-1,1234567,2024\n,deal-2,\"7654321; 1000000\",Won\n")
+
+	rows, err := readRows(path, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []importRow{
+		{line: 2, columns: map[string]string{"item_id": "", "external_id": "deal-1", "customer": "1234567", "stage": "2024"}},
+		{line: 3, columns: map[string]string{"item_id": "", "external_id": "deal-2", "customer": "7654321; 1000000", "stage": "Won"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestReadRowsJSONL(t *testing.T) {
+	path := writeFile(t, "items.jsonl", `{"external_id":"deal-1","customer":1234567,"amount":1234567.89,"stage":"2024","note":null}
+
+{"external_id":"deal-2","customer":"7654321","big":12345678901234567890,"done":true}
+`)
+
+	rows, err := readRows(path, "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []importRow{
+		{line: 1, columns: map[string]string{"external_id": "deal-1", "customer": "1234567", "amount": "1234567.89", "stage": "2024"}},
+		{line: 3, columns: map[string]string{"external_id": "deal-2", "customer": "7654321", "big": "12345678901234567890", "done": "true"}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %+v, want %+v", rows, want)
+	}
+}
+
+func TestReadRowsInvalidJSONL(t *testing.T) {
+	path := writeFile(t, "items.jsonl", "{\"external_id\":\"deal-1\"}\n{oops\n")
+
+	if _, err := readRows(path, "jsonl"); err == nil {
+		t.Error("read invalid JSON without error")
+	}
+}
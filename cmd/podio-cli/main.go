@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kayteh/podio-go"
 )
@@ -16,21 +18,43 @@ func main() {
 	clientID := os.Getenv("PODIO_CLIENT_ID")
 	clientSecret := os.Getenv("PODIO_CLIENT_SECRET")
 
-	if username == "" || password == "" || clientID == "" || clientSecret == "" {
-		fmt.Println("PODIO_USERNAME, PODIO_PASSWORD, PODIO_CLIENT_ID and PODIO_CLIENT_SECRET must be set")
+	if clientID == "" || clientSecret == "" {
+		fmt.Println("PODIO_CLIENT_ID and PODIO_CLIENT_SECRET must be set")
 		os.Exit(1)
 	}
 
 	client := podio.NewClient(podio.ClientOptions{
-		ApiKey:    clientID,
-		ApiSecret: clientSecret,
-		UserAgent: "podio-cli",
+		ApiKey:     clientID,
+		ApiSecret:  clientSecret,
+		UserAgent:  "podio-cli",
+		TokenStore: podio.NewFileTokenStore(tokenFile()),
 	})
 
-	err := client.AuthenticateWithCredentials(username, password)
+	loaded, err := client.LoadToken()
 	if err != nil {
-		fmt.Println("Failed to authenticate:", err)
-		os.Exit(1)
+		fmt.Println("Failed to load token:", err)
+	}
+
+	// A saved token whose refresh fails, e.g. because it was revoked, is
+	// replaced by logging in again.
+	if token := client.Token(); loaded && !token.ExpiresAt.IsZero() && time.Until(token.ExpiresAt) < time.Minute {
+		if err := client.RefreshToken(); err != nil {
+			fmt.Println("Failed to refresh token, logging in again:", err)
+			loaded = false
+		}
+	}
+
+	if !loaded {
+		if username == "" || password == "" {
+			fmt.Println("PODIO_USERNAME and PODIO_PASSWORD must be set")
+			os.Exit(1)
+		}
+
+		err = client.AuthenticateWithCredentials(username, password)
+		if err != nil {
+			fmt.Println("Failed to authenticate:", err)
+			os.Exit(1)
+		}
 	}
 
 	outputEncoder := json.NewEncoder(os.Stdout)
@@ -135,6 +159,24 @@ func main() {
 		outputEncoder.Encode(application)
 	}
 
+	if os.Args[1] == "item" {
+		itemID := os.Args[2]
+		item, err := client.GetItem(itemID)
+		if err != nil {
+			fmt.Println("Failed to get item: ", err)
+			os.Exit(1)
+		}
+		outputEncoder.Encode(item)
+	}
+
+	if os.Args[1] == "items" {
+		runItems(client, os.Args[2:])
+	}
+
+	if os.Args[1] == "drift" {
+		runDrift(client, os.Args[2:])
+	}
+
 	if os.Args[1] == "applications" {
 		spaceID := os.Args[2]
 		var applications *[]podio.Application
@@ -216,3 +258,17 @@ func main() {
 	}
 
 }
+
+// tokenFile is where the session is kept between runs, PODIO_TOKEN_FILE if set.
+func tokenFile() string {
+	if path := os.Getenv("PODIO_TOKEN_FILE"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "podio-cli", "token.json")
+}
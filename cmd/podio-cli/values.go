@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+// valueSeparator separates multiple values of a field in a single column.
+const valueSeparator = "; "
+
+// formatValues renders the values of a field as a single column.
+func formatValues(values []podio.FieldValue) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatValue(value))
+	}
+
+	return strings.Join(formatted, valueSeparator)
+}
+
+func formatValue(value podio.FieldValue) string {
+	switch v := value.(type) {
+	case *podio.TextValue:
+		return v.Value
+	case *podio.NumberValue:
+		return strconv.FormatFloat(v.Value, 'f', -1, 64)
+	case *podio.MoneyValue:
+		return strings.TrimSpace(strconv.FormatFloat(v.Value, 'f', -1, 64) + " " + v.Currency)
+	case *podio.DateValue:
+		layout := "2006-01-02"
+		if v.HasTime {
+			layout = "2006-01-02 15:04:05"
+		}
+		if v.End.IsZero() {
+			return v.Start.Format(layout)
+		}
+		return v.Start.Format(layout) + "/" + v.End.Format(layout)
+	case *podio.CategoryValue:
+		return v.Text
+	case *podio.AppReferenceValue:
+		return strconv.Itoa(v.ItemID)
+	case *podio.ContactValue:
+		return strconv.Itoa(v.ProfileID)
+	case *podio.CalculationValue:
+		return v.Value
+	case *podio.LocationValue:
+		return v.Value
+	case *podio.DurationValue:
+		return strconv.FormatInt(int64(v.Value/time.Second), 10)
+	case *podio.ProgressValue:
+		return strconv.Itoa(v.Value)
+	case *podio.EmailValue:
+		return typedValue(v.Type, v.Value)
+	case *podio.PhoneValue:
+		return typedValue(v.Type, v.Value)
+	case *podio.LinkValue:
+		return v.URL
+	case *podio.ImageValue:
+		return strconv.Itoa(v.FileID)
+	case *podio.TelValue:
+		return v.Number
+	case *podio.RawValue:
+		return string(v.Raw)
+	}
+
+	return ""
+}
+
+// parseValues parses a column formatted by formatValues into values for field.
+func parseValues(field *podio.Field, column string) ([]podio.FieldValue, error) {
+	if strings.TrimSpace(column) == "" {
+		return nil, nil
+	}
+
+	parts := []string{column}
+	if field.Type != podio.FieldTypeText && field.Type != podio.FieldTypeLocation {
+		parts = strings.Split(column, valueSeparator)
+	}
+
+	values := make([]podio.FieldValue, 0, len(parts))
+	for _, part := range parts {
+		value, err := parseValue(field, strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func parseValue(field *podio.Field, s string) (podio.FieldValue, error) {
+	switch field.Type {
+	case podio.FieldTypeText:
+		return podio.TextValue{Value: s}, nil
+	case podio.FieldTypeNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		return podio.NumberValue{Value: n}, err
+	case podio.FieldTypeMoney:
+		amount, currency := s, ""
+		if i := strings.LastIndex(s, " "); i >= 0 {
+			amount, currency = s[:i], s[i+1:]
+		}
+		n, err := strconv.ParseFloat(amount, 64)
+		return podio.MoneyValue{Value: n, Currency: currency}, err
+	case podio.FieldTypeDate:
+		return parseDate(s)
+	case podio.FieldTypeCategory:
+		// Exports write option texts, which may themselves be numbers, so
+		// ids are only tried when no text matches.
+		for _, option := range field.CategoryOptions() {
+			if strings.EqualFold(option.Text, s) && option.Status != "deleted" {
+				return podio.CategoryValue{ID: option.ID}, nil
+			}
+		}
+		if id, err := strconv.Atoi(s); err == nil {
+			return podio.CategoryValue{ID: id}, nil
+		}
+		return nil, fmt.Errorf("no option %q", s)
+	case podio.FieldTypeApp:
+		id, err := strconv.Atoi(s)
+		return podio.AppReferenceValue{ItemID: id}, err
+	case podio.FieldTypeContact:
+		id, err := strconv.Atoi(s)
+		return podio.ContactValue{ProfileID: id}, err
+	case podio.FieldTypeLocation:
+		return podio.LocationValue{Value: s}, nil
+	case podio.FieldTypeDuration:
+		seconds, err := strconv.ParseInt(s, 10, 64)
+		return podio.DurationValue{Value: time.Duration(seconds) * time.Second}, err
+	case podio.FieldTypeProgress:
+		n, err := strconv.Atoi(s)
+		return podio.ProgressValue{Value: n}, err
+	case podio.FieldTypeEmail:
+		valueType, value := parseTypedValue(s)
+		return podio.EmailValue{Type: valueType, Value: value}, nil
+	case podio.FieldTypePhone:
+		valueType, value := parseTypedValue(s)
+		return podio.PhoneValue{Type: valueType, Value: value}, nil
+	case podio.FieldTypeLink:
+		return podio.LinkValue{URL: s}, nil
+	case podio.FieldTypeImage:
+		id, err := strconv.Atoi(s)
+		return podio.ImageValue{FileID: id}, err
+	case podio.FieldTypeTel:
+		return podio.TelValue{Number: s}, nil
+	}
+
+	if !json.Valid([]byte(s)) {
+		return nil, fmt.Errorf("%s values must be raw JSON", field.Type)
+	}
+	return podio.RawValue{Type: field.Type, Raw: json.RawMessage(s)}, nil
+}
+
+// typedValue formats an email or phone value with its type, e.g.
+// "work:jane@example.com".
+func typedValue(valueType, value string) string {
+	if valueType == "" {
+		return value
+	}
+
+	return valueType + ":" + value
+}
+
+// parseTypedValue parses a value formatted by typedValue. Values without a
+// type are of type "other".
+func parseTypedValue(s string) (string, string) {
+	i := strings.Index(s, ":")
+	if i <= 0 {
+		return "other", s
+	}
+
+	for _, r := range s[:i] {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return "other", s
+		}
+	}
+
+	return s[:i], strings.TrimSpace(s[i+1:])
+}
+
+func parseDate(s string) (podio.FieldValue, error) {
+	start, end := s, ""
+	if i := strings.Index(s, "/"); i >= 0 {
+		start, end = s[:i], s[i+1:]
+	}
+
+	value := podio.DateValue{HasTime: strings.Contains(start, " ")}
+
+	var err error
+	value.Start, err = parseDateTime(start)
+	if err != nil {
+		return nil, err
+	}
+
+	if end != "" {
+		value.End, err = parseDateTime(end)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+func parseDateTime(s string) (time.Time, error) {
+	if strings.Contains(s, " ") {
+		return time.Parse("2006-01-02 15:04:05", s)
+	}
+
+	return time.Parse("2006-01-02", s)
+}
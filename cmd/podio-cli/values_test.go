@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+func TestValueRoundTrip(t *testing.T) {
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	moment := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+
+	category := &podio.Field{Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+		Settings: map[string]interface{}{"options": []interface{}{
+			map[string]interface{}{"id": 1, "text": "2024", "status": "active"},
+			map[string]interface{}{"id": 2024, "text": "Other", "status": "active"},
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		field    *podio.Field
+		exported podio.FieldValue
+		column   string
+		imported podio.FieldValue
+	}{
+		{"text", &podio.Field{Type: podio.FieldTypeText}, &podio.TextValue{Value: "Hello; world"}, "Hello; world", podio.TextValue{Value: "Hello; world"}},
+		{"number", &podio.Field{Type: podio.FieldTypeNumber}, &podio.NumberValue{Value: 1234567.5}, "1234567.5", podio.NumberValue{Value: 1234567.5}},
+		{"money", &podio.Field{Type: podio.FieldTypeMoney}, &podio.MoneyValue{Value: 1234567.89, Currency: "EUR"}, "1234567.89 EUR", podio.MoneyValue{Value: 1234567.89, Currency: "EUR"}},
+		{"date", &podio.Field{Type: podio.FieldTypeDate}, &podio.DateValue{Start: day}, "2024-03-04", podio.DateValue{Start: day}},
+		{"date with time", &podio.Field{Type: podio.FieldTypeDate}, &podio.DateValue{Start: moment, HasTime: true}, "2024-03-04 15:30:00", podio.DateValue{Start: moment, HasTime: true}},
+		{"date range", &podio.Field{Type: podio.FieldTypeDate}, &podio.DateValue{Start: day, End: day.AddDate(0, 0, 2)}, "2024-03-04/2024-03-06", podio.DateValue{Start: day, End: day.AddDate(0, 0, 2)}},
+		{"numeric category text", category, &podio.CategoryValue{ID: 1, Text: "2024"}, "2024", podio.CategoryValue{ID: 1}},
+		{"category text", category, &podio.CategoryValue{ID: 2024, Text: "Other"}, "Other", podio.CategoryValue{ID: 2024}},
+		{"app reference", &podio.Field{Type: podio.FieldTypeApp}, &podio.AppReferenceValue{ItemID: 1234567890}, "1234567890", podio.AppReferenceValue{ItemID: 1234567890}},
+		{"contact", &podio.Field{Type: podio.FieldTypeContact}, &podio.ContactValue{ProfileID: 98765432}, "98765432", podio.ContactValue{ProfileID: 98765432}},
+		{"location", &podio.Field{Type: podio.FieldTypeLocation}, &podio.LocationValue{Value: "1 Main St, Springfield"}, "1 Main St, Springfield", podio.LocationValue{Value: "1 Main St, Springfield"}},
+		{"duration", &podio.Field{Type: podio.FieldTypeDuration}, &podio.DurationValue{Value: 90 * time.Minute}, "5400", podio.DurationValue{Value: 90 * time.Minute}},
+		{"progress", &podio.Field{Type: podio.FieldTypeProgress}, &podio.ProgressValue{Value: 75}, "75", podio.ProgressValue{Value: 75}},
+		{"email", &podio.Field{Type: podio.FieldTypeEmail}, &podio.EmailValue{Type: "work", Value: "jane@example.com"}, "work:jane@example.com", podio.EmailValue{Type: "work", Value: "jane@example.com"}},
+		{"phone", &podio.Field{Type: podio.FieldTypePhone}, &podio.PhoneValue{Type: "mobile", Value: "+1 (555) 0100"}, "mobile:+1 (555) 0100", podio.PhoneValue{Type: "mobile", Value: "+1 (555) 0100"}},
+		{"link", &podio.Field{Type: podio.FieldTypeLink}, &podio.LinkValue{EmbedID: 5, URL: "https://example.com", Title: "Example"}, "https://example.com", podio.LinkValue{URL: "https://example.com"}},
+		{"image", &podio.Field{Type: podio.FieldTypeImage}, &podio.ImageValue{FileID: 1234567}, "1234567", podio.ImageValue{FileID: 1234567}},
+		{"tel", &podio.Field{Type: podio.FieldTypeTel}, &podio.TelValue{Number: "+15550100"}, "+15550100", podio.TelValue{Number: "+15550100"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column := formatValues([]podio.FieldValue{tt.exported})
+			if column != tt.column {
+				t.Errorf("formatted %q, want %q", column, tt.column)
+			}
+
+			values, err := parseValues(tt.field, column)
+			if err != nil {
+				t.Fatalf("parseValues(%q): %v", column, err)
+			}
+			if len(values) != 1 || !reflect.DeepEqual(values[0], tt.imported) {
+				t.Errorf("parsed %#v, want %#v", values, tt.imported)
+			}
+		})
+	}
+}
+
+func TestParseValuesSplitsMultipleValues(t *testing.T) {
+	values, err := parseValues(&podio.Field{Type: podio.FieldTypeApp}, "1234567; 7654321")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []podio.FieldValue{podio.AppReferenceValue{ItemID: 1234567}, podio.AppReferenceValue{ItemID: 7654321}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("parsed %#v, want %#v", values, want)
+	}
+}
+
+func TestParseValueErrors(t *testing.T) {
+	category := &podio.Field{Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+		Settings: map[string]interface{}{"options": []interface{}{map[string]interface{}{"id": 1, "text": "New"}}},
+	}}
+
+	tests := []struct {
+		field  *podio.Field
+		column string
+	}{
+		{category, "Missing"},
+		{&podio.Field{Type: podio.FieldTypeApp}, "1.234567e+06"},
+		{&podio.Field{Type: podio.FieldTypeNumber}, "many"},
+		{&podio.Field{Type: podio.FieldTypeDate}, "04/03/2024"},
+		{&podio.Field{Type: "question"}, "not json"},
+	}
+
+	for _, tt := range tests {
+		if _, err := parseValues(tt.field, tt.column); err == nil {
+			t.Errorf("%s %q: parsed without error", tt.field.Type, tt.column)
+		}
+	}
+}
+
+func TestParseTypedValueWithoutType(t *testing.T) {
+	value, err := parseValue(&podio.Field{Type: podio.FieldTypeEmail}, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (podio.EmailValue{Type: "other", Value: "jane@example.com"}); value != want {
+		t.Errorf("parsed %#v, want %#v", value, want)
+	}
+}
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kayteh/podio-go"
+)
+
+// genField is an app field as it appears in the generated code.
+type genField struct {
+	podio.Field
+	// Name is the Go name of the field.
+	Name string
+	// GoType is the type of the struct field holding its values.
+	GoType string
+	// Options are the options of category fields.
+	Options []genOption
+}
+
+type genOption struct {
+	Name string
+	ID   int
+	Text string
+}
+
+type genApp struct {
+	Package  string
+	TypeName string
+	App      *podio.Application
+	Fields   []genField
+	Imports  []string
+}
+
+// generate renders the Go source for an app.
+func generate(app *podio.Application, pkg, typeName string) ([]byte, error) {
+	if typeName == "" {
+		typeName = goName(app.Config.ItemName)
+	}
+	if typeName == "" {
+		typeName = goName(app.Config.Name)
+	}
+	if typeName == "" {
+		return nil, fmt.Errorf("app %d has no name to derive a type name from, use -type", app.AppID)
+	}
+
+	data := genApp{Package: pkg, TypeName: typeName, App: app}
+	usesTime := false
+	used := map[string]bool{"ItemID": true, "ExternalID": true}
+
+	// idents are the package level identifiers of the generated code, which
+	// option constants mustn't collide with.
+	idents := identSet{}
+	for _, ident := range []string{typeName, typeName + "AppID", lowerFirst(typeName) + "App", typeName + "FromItem",
+		"Get" + typeName, "Create" + typeName, "Update" + typeName, "Delete" + typeName} {
+		idents.claim(ident, ident)
+	}
+
+	for _, field := range app.Fields {
+		if field.Status == "deleted" {
+			continue
+		}
+
+		name := goName(field.ExternalID)
+		if name == "" {
+			name = goName(field.Config.Label)
+		}
+		if name == "" || used[name] {
+			name = fmt.Sprintf("%sField%d", name, field.FieldID)
+		}
+		used[name] = true
+		idents.claim(typeName+name+"FieldID", "")
+		idents.claim(typeName+name+"ExternalID", "")
+
+		f := genField{Field: field, Name: name, GoType: goType(field)}
+		if strings.Contains(f.GoType, "time.") {
+			usesTime = true
+		}
+
+		data.Fields = append(data.Fields, f)
+	}
+
+	// Options are named once every field constant is claimed, so an option
+	// can't take the name of a later field's constant.
+	for n := range data.Fields {
+		f := &data.Fields[n]
+		if f.Type != podio.FieldTypeCategory {
+			continue
+		}
+
+		for _, option := range f.CategoryOptions() {
+			if option.Status == "deleted" {
+				continue
+			}
+
+			name := ""
+			if text := goName(option.Text); text != "" {
+				name = typeName + f.Name + text
+			}
+			name = idents.claim(name, fmt.Sprintf("%s%sOption%d", typeName, f.Name, option.ID))
+
+			f.Options = append(f.Options, genOption{Name: name, ID: option.ID, Text: option.Text})
+		}
+	}
+
+	data.Imports = []string{"context", "strconv"}
+	if usesTime {
+		data.Imports = append(data.Imports, "time")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := sourceTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w\n%s", err, buf.String())
+	}
+
+	return source, nil
+}
+
+// identSet is a set of identifiers taken in the generated code.
+type identSet map[string]bool
+
+// claim takes name, or fallback if name is empty or taken. A fallback that is
+// taken too gets a numeric suffix. An empty fallback claims name as is.
+func (s identSet) claim(name, fallback string) string {
+	if fallback == "" || (name != "" && !s[name]) {
+		s[name] = true
+		return name
+	}
+
+	name = fallback
+	for n := 2; s[name]; n++ {
+		name = fmt.Sprintf("%s_%d", fallback, n)
+	}
+
+	s[name] = true
+	return name
+}
+
+// lowerFirst lowercases the first letter of an exported name.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// goType returns the Go type generated for values of field.
+func goType(field podio.Field) string {
+	multiple := false
+	if settings, ok := field.Config.Settings.(map[string]interface{}); ok {
+		multiple, _ = settings["multiple"].(bool)
+	}
+
+	switch field.Type {
+	case podio.FieldTypeText, podio.FieldTypeLocation, podio.FieldTypeLink, podio.FieldTypeTel, podio.FieldTypeCalculation:
+		return "string"
+	case podio.FieldTypeNumber:
+		return "float64"
+	case podio.FieldTypeMoney:
+		return "*podio.MoneyValue"
+	case podio.FieldTypeProgress:
+		return "int"
+	case podio.FieldTypeDuration:
+		return "time.Duration"
+	case podio.FieldTypeDate:
+		return "*podio.DateValue"
+	case podio.FieldTypeCategory:
+		if multiple {
+			return "[]int"
+		}
+		return "int"
+	case podio.FieldTypeApp, podio.FieldTypeContact, podio.FieldTypeImage:
+		return "[]int"
+	case podio.FieldTypeEmail:
+		return "[]podio.EmailValue"
+	case podio.FieldTypePhone:
+		return "[]podio.PhoneValue"
+	}
+
+	return "[]podio.RawValue"
+}
+
+// goName turns an external id or label such as "customer-name" into an
+// exported Go name such as "CustomerName".
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if r > unicode.MaxASCII {
+			continue
+		}
+
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('F')
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(template.FuncMap{
+	"lower": lowerFirst,
+	// comment puts names and labels, which may span lines, on one line.
+	"comment": func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	},
+}).Parse(`// Code generated by podio-gen from app {{.App.AppID}} ({{printf "%q" .App.Config.Name}}). DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+
+	"github.com/kayteh/podio-go"
+)
+
+// {{.TypeName}}AppID is the id of the {{comment .App.Config.Name}} app.
+const {{.TypeName}}AppID = {{.App.AppID}}
+
+// Field ids of the {{comment .App.Config.Name}} app.
+const (
+{{- range .Fields}}
+	{{$.TypeName}}{{.Name}}FieldID = {{.FieldID}}
+{{- end}}
+)
+
+// Field external ids of the {{comment .App.Config.Name}} app.
+const (
+{{- range .Fields}}
+	{{$.TypeName}}{{.Name}}ExternalID = {{printf "%q" .ExternalID}}
+{{- end}}
+)
+{{range .Fields}}{{if .Options}}
+// Options of the {{comment .Config.Label}} category field.
+const (
+{{- range .Options}}
+	{{.Name}} = {{.ID}} // {{comment .Text}}
+{{- end}}
+)
+{{end}}{{end}}
+// {{.TypeName}} is an item of the {{comment .App.Config.Name}} app.
+type {{.TypeName}} struct {
+	ItemID     int    ` + "`podio:\"-\"`" + `
+	ExternalID string ` + "`podio:\"-\"`" + `
+{{range .Fields}}
+	// {{.Name}} is the {{comment .Config.Label}} {{.Type}} field.
+	{{.Name}} {{.GoType}} ` + "`podio:\"{{.ExternalID}},omitempty\"`" + `
+{{- end}}
+}
+
+// {{lower .TypeName}}App is the schema {{.TypeName}} is mapped with.
+var {{lower .TypeName}}App = &podio.Application{
+	AppID: {{.App.AppID}},
+	Fields: []podio.Field{
+{{- range .Fields}}
+		{FieldID: {{.FieldID}}, ExternalID: {{printf "%q" .ExternalID}}, Type: {{printf "%q" .Type}}},
+{{- end}}
+	},
+}
+
+// {{.TypeName}}FromItem converts an item of the {{comment .App.Config.Name}} app.
+func {{.TypeName}}FromItem(item *podio.Item) (*{{.TypeName}}, error) {
+	v := &{{.TypeName}}{ItemID: item.ItemID, ExternalID: item.ExternalID}
+	if err := podio.Unmarshal({{lower .TypeName}}App, item, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Get{{.TypeName}} gets an item of the {{comment .App.Config.Name}} app.
+func Get{{.TypeName}}(ctx context.Context, c *podio.Client, itemID int) (*{{.TypeName}}, error) {
+	item, err := c.GetItemContext(ctx, strconv.Itoa(itemID))
+	if err != nil {
+		return nil, err
+	}
+	return {{.TypeName}}FromItem(item)
+}
+
+// Create{{.TypeName}} creates an item in the {{comment .App.Config.Name}} app.
+func Create{{.TypeName}}(ctx context.Context, c *podio.Client, v *{{.TypeName}}) (*{{.TypeName}}, error) {
+	fields, err := podio.Marshal({{lower .TypeName}}App, v)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := c.CreateItemContext(ctx, strconv.Itoa({{.TypeName}}AppID), podio.CreateItemParams{ExternalID: v.ExternalID, Fields: fields})
+	if err != nil {
+		return nil, err
+	}
+	return {{.TypeName}}FromItem(item)
+}
+
+// Update{{.TypeName}} updates the item v.ItemID. Fields with zero values are left unchanged.
+func Update{{.TypeName}}(ctx context.Context, c *podio.Client, v *{{.TypeName}}) (*{{.TypeName}}, error) {
+	fields, err := podio.Marshal({{lower .TypeName}}App, v)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := c.UpdateItemContext(ctx, strconv.Itoa(v.ItemID), podio.CreateItemParams{ExternalID: v.ExternalID, Fields: fields})
+	if err != nil {
+		return nil, err
+	}
+	return {{.TypeName}}FromItem(item)
+}
+
+// Delete{{.TypeName}} deletes an item of the {{comment .App.Config.Name}} app.
+func Delete{{.TypeName}}(ctx context.Context, c *podio.Client, itemID int) error {
+	return c.DeleteItemContext(ctx, strconv.Itoa(itemID))
+}
+`))
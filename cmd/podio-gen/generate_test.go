@@ -0,0 +1,139 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+)
+
+// typeCheck parses and type checks generated source, and returns its package.
+func typeCheck(t *testing.T, source []byte) *types.Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "gen.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated source doesn't parse: %v\n%s", err, source)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("generated source doesn't type check: %v\n%s", err, source)
+	}
+	return pkg
+}
+
+func TestGenerateTypeChecks(t *testing.T) {
+	app := &podio.Application{
+		AppID:  1,
+		Config: podio.AppConfig{Name: "Deals", ItemName: "Deal"},
+		Fields: []podio.Field{
+			{FieldID: 10, ExternalID: "title", Type: podio.FieldTypeText},
+			{FieldID: 11, ExternalID: "amount", Type: podio.FieldTypeMoney},
+			{FieldID: 12, ExternalID: "closes", Type: podio.FieldTypeDate},
+			{FieldID: 13, ExternalID: "effort", Type: podio.FieldTypeDuration},
+			{FieldID: 14, ExternalID: "customer", Type: podio.FieldTypeApp},
+			{FieldID: 15, ExternalID: "email", Type: podio.FieldTypeEmail},
+			{FieldID: 16, ExternalID: "phone", Type: podio.FieldTypePhone},
+			{FieldID: 17, ExternalID: "notes", Type: "unknown"},
+			{FieldID: 18, ExternalID: "tags", Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+				Settings: map[string]interface{}{"multiple": true, "options": []interface{}{
+					map[string]interface{}{"id": 1, "text": "Hot"},
+				}},
+			}},
+		},
+	}
+
+	source, err := generate(app, "deals", "")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	pkg := typeCheck(t, source)
+	for _, name := range []string{"Deal", "DealAppID", "DealTitleFieldID", "DealTagsHot", "GetDeal", "CreateDeal", "UpdateDeal", "DeleteDeal"} {
+		if pkg.Scope().Lookup(name) == nil {
+			t.Errorf("generated source declares no %s", name)
+		}
+	}
+}
+
+func TestGenerateDeduplicatesIdentifiers(t *testing.T) {
+	options := func(texts ...string) map[string]interface{} {
+		var entries []interface{}
+		for n, text := range texts {
+			entries = append(entries, map[string]interface{}{"id": n + 1, "text": text})
+		}
+		return map[string]interface{}{"options": entries}
+	}
+
+	app := &podio.Application{
+		AppID:  1,
+		Config: podio.AppConfig{Name: "Deals", ItemName: "Deal"},
+		Fields: []podio.Field{
+			// "External ID" and "Field ID" collide with the field's own constants,
+			// and "External ID" then with the option named "Option3".
+			{FieldID: 10, ExternalID: "stage", Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+				Settings: options("Won", "Option3", "External ID", "won!", "Field ID"),
+			}},
+			// "ID" collides with DealAppID.
+			{FieldID: 11, ExternalID: "app", Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+				Settings: options("ID", "B C"),
+			}},
+			// "C" collides with option "B C" of the field app.
+			{FieldID: 12, ExternalID: "app-b", Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+				Settings: options("C"),
+			}},
+			// "Field ID" of the field stage collides with this field's constant.
+			{FieldID: 13, ExternalID: "stage-field", Type: podio.FieldTypeText},
+		},
+	}
+
+	source, err := generate(app, "deals", "")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	pkg := typeCheck(t, source)
+	for _, name := range []string{"DealStageWon", "DealStageOption3", "DealStageOption3_2", "DealStageOption4", "DealStageOption5", "DealAppOption1", "DealAppBC", "DealAppBOption1", "DealStageFieldFieldID"} {
+		if pkg.Scope().Lookup(name) == nil {
+			t.Errorf("generated source declares no %s:\n%s", name, source)
+		}
+	}
+}
+
+func TestGenerateMultilineComments(t *testing.T) {
+	app := &podio.Application{
+		AppID:  1,
+		Config: podio.AppConfig{Name: "Deals\nand leads", ItemName: "Deal"},
+		Fields: []podio.Field{{
+			FieldID:    10,
+			ExternalID: "stage",
+			Type:       podio.FieldTypeCategory,
+			Config: podio.FieldConfig{
+				Label: "Stage\nof the deal",
+				Settings: map[string]interface{}{"options": []interface{}{
+					map[string]interface{}{"id": 1, "text": "Won\r\n(closed)"},
+				}},
+			},
+		}},
+	}
+
+	source, err := generate(app, "deals", "")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	typeCheck(t, source)
+
+	for _, want := range []string{"the Deals and leads app", "Options of the Stage of the deal category field", "// Won (closed)"} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("generated source has no %q:\n%s", want, source)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package podio
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
 
 var (
 	// ErrTrustLevel is returned when the API key trust level is not high enough.
@@ -8,4 +14,96 @@ var (
 
 	// ErrNotFound is returned when the requested resource is not found.
 	ErrNotFound = fmt.Errorf("podio-go: not found")
+
+	// ErrUnauthorized is returned when the client is not authenticated, or its token was rejected.
+	ErrUnauthorized = fmt.Errorf("podio-go: unauthorized")
+
+	// ErrForbidden is returned when the authenticated user or app may not perform the call.
+	ErrForbidden = fmt.Errorf("podio-go: forbidden")
+
+	// ErrRateLimited is returned when the rate limit of the API key or user has been hit.
+	ErrRateLimited = fmt.Errorf("podio-go: rate limited")
+
+	// ErrConflict is returned when the call conflicts with the current state of the resource.
+	ErrConflict = fmt.Errorf("podio-go: conflict")
+
+	// ErrGone is returned when the requested resource has been deleted.
+	ErrGone = fmt.Errorf("podio-go: gone")
 )
+
+// statusRateLimited is the non-standard status Podio uses when a rate limit is hit.
+const statusRateLimited = 420
+
+// APIError is returned when Podio responds to a call with an error. Use
+// errors.Is to check it against the sentinel errors of this package.
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// Method and Path identify the failed request.
+	Method string `json:"-"`
+	Path   string `json:"-"`
+
+	// "error": The error code, e.g. "not_found" or "forbidden",
+	Code string `json:"error"`
+	// "error_description": A human readable description of the error,
+	Description string `json:"error_description"`
+	// "error_detail": Additional detail for some errors, e.g. which field failed validation,
+	Detail interface{} `json:"error_detail"`
+	// "error_parameters": The parameters the error description was built from,
+	Parameters map[string]interface{} `json:"error_parameters"`
+
+	// Payload is the raw response body, for responses that aren't Podio errors.
+	Payload string `json:"-"`
+}
+
+// newAPIError reads an error response. The body is not closed.
+func newAPIError(resp *http.Response, method, path string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Path:       path,
+	}
+
+	output, _ := ioutil.ReadAll(resp.Body)
+	if json.Unmarshal(output, apiErr) != nil || apiErr.Code == "" {
+		apiErr.Payload = string(output)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	message := e.Description
+	if message == "" {
+		message = e.Payload
+	}
+
+	if e.Code != "" {
+		return fmt.Sprintf("podio-go: %s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, e.Code, message)
+	}
+
+	return fmt.Sprintf("podio-go: %s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), message)
+}
+
+// Is matches the sentinel errors of this package, based on the status and
+// error code of the response.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == "not_found"
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.Code == "unauthorized" || e.Code == "invalid_grant"
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.Code == "forbidden"
+	case ErrTrustLevel:
+		return e.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(e.Description), "trust level")
+	case ErrRateLimited:
+		return e.StatusCode == statusRateLimited || e.StatusCode == http.StatusTooManyRequests || e.Code == "rate_limit"
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict || e.Code == "conflict"
+	case ErrGone:
+		return e.StatusCode == http.StatusGone || e.Code == "gone"
+	}
+
+	return false
+}
@@ -0,0 +1,347 @@
+package podio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SpaceBundle is a self-contained, portable template of a space and its
+// apps, as produced by ExportSpace. App reference fields refer to apps of
+// the bundle by their Ref instead of their id, in an "app_ref" entry of the
+// field's referenced_apps settings.
+type SpaceBundle struct {
+	Space Space       `json:"space"`
+	Apps  []BundleApp `json:"apps"`
+}
+
+// BundleApp is an app of a SpaceBundle.
+type BundleApp struct {
+	// Ref identifies the app within the bundle.
+	Ref    string    `json:"ref"`
+	Config AppConfig `json:"config"`
+	Fields []Field   `json:"fields"`
+}
+
+// ExportSpace exports a space and its active apps with their fields, without
+// ids, statuses or users that only exist in the source organization.
+// References to apps outside the space are kept as ids.
+func (c *Client) ExportSpace(spaceID string) (*SpaceBundle, error) {
+	return c.ExportSpaceContext(context.Background(), spaceID)
+}
+
+func (c *Client) ExportSpaceContext(ctx context.Context, spaceID string) (*SpaceBundle, error) {
+	space, err := c.GetSpaceContext(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to export space: %w", err)
+	}
+
+	listed, err := c.GetApplicationsContext(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to export space: %w", err)
+	}
+
+	bundle := &SpaceBundle{
+		Space: Space{
+			Name:            space.Name,
+			Privacy:         space.Privacy,
+			AutoJoin:        space.AutoJoin,
+			URLLabel:        space.URLLabel,
+			PostOnNewApp:    space.PostOnNewApp,
+			PostOnNewMember: space.PostOnNewMember,
+		},
+		Apps: []BundleApp{},
+	}
+
+	refs := map[int]string{}
+	used := map[string]bool{}
+	var apps []*Application
+
+	for _, listedApp := range *listed {
+		app, err := c.GetApplicationContext(ctx, strconv.Itoa(listedApp.AppID))
+		if err != nil {
+			return nil, fmt.Errorf("podio-go: failed to export app %d: %w", listedApp.AppID, err)
+		}
+		apps = append(apps, app)
+
+		ref := app.Config.ExternalID
+		if ref == "" {
+			ref = bundleRef(app.Config.Name)
+		}
+		if ref == "" {
+			ref = "app"
+		}
+		base := ref
+		for n := 2; used[ref]; n++ {
+			ref = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[ref] = true
+		refs[app.AppID] = ref
+	}
+
+	for _, app := range apps {
+		config, fields := portableApp(app)
+		bundleApp := BundleApp{Ref: refs[app.AppID], Config: config, Fields: []Field{}}
+
+		for _, field := range fields {
+			if field.Type == FieldTypeApp {
+				field.Config.Settings = mapReferencedApps(field.Config.Settings, func(entry map[string]interface{}) bool {
+					id, _ := entry["app_id"].(float64)
+					if ref, ok := refs[int(id)]; ok {
+						delete(entry, "app_id")
+						entry["app_ref"] = ref
+					}
+					return true
+				})
+			}
+			bundleApp.Fields = append(bundleApp.Fields, field)
+		}
+
+		bundle.Apps = append(bundle.Apps, bundleApp)
+	}
+
+	return bundle, nil
+}
+
+// ImportSpace creates the space and apps of bundle in an organization. Apps
+// are created after the apps they reference. Apps in a reference cycle are
+// created without the references to apps not created yet, which are set
+// once all apps exist.
+//
+// The import isn't transactional. If it fails after the space is created,
+// the partially imported space is returned along with the error, which
+// names the app or field that failed; delete it with DeleteSpace to start
+// over.
+func (c *Client) ImportSpace(orgID string, bundle *SpaceBundle) (*Space, error) {
+	return c.ImportSpaceContext(context.Background(), orgID, bundle)
+}
+
+func (c *Client) ImportSpaceContext(ctx context.Context, orgID string, bundle *SpaceBundle) (*Space, error) {
+	order, err := bundleOrder(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := strconv.Atoi(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: invalid org id, must parse to int: %s", orgID)
+	}
+
+	space, err := c.CreateSpaceContext(ctx, CreateSpaceParams{
+		Name:            bundle.Space.Name,
+		OrgID:           org,
+		Privacy:         bundle.Space.Privacy,
+		PostOnNewApp:    bundle.Space.PostOnNewApp,
+		PostOnNewMember: bundle.Space.PostOnNewMember,
+		AutoJoin:        bundle.Space.AutoJoin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to import space: %w", err)
+	}
+
+	type pendingField struct {
+		app   *Application
+		field Field
+		spec  Field
+	}
+
+	created := map[string]*Application{}
+	var pending []pendingField
+
+	for _, bundleApp := range order {
+		fields := make([]Field, 0, len(bundleApp.Fields))
+		var unresolved []int
+
+		for n, field := range bundleApp.Fields {
+			if field.Type == FieldTypeApp {
+				resolved := true
+				field.Config.Settings = resolveReferences(field.Config.Settings, created, &resolved)
+				if !resolved {
+					unresolved = append(unresolved, n)
+				}
+			}
+			fields = append(fields, field)
+		}
+
+		app, err := c.CreateApplicationContext(ctx, strconv.Itoa(space.ID), CreateApplicationParams{Config: bundleApp.Config, Fields: fields})
+		if err != nil {
+			return space, fmt.Errorf("podio-go: failed to import app %q: %w", bundleApp.Ref, err)
+		}
+		created[bundleApp.Ref] = app
+
+		for _, n := range unresolved {
+			field := createdField(app, bundleApp.Fields[n])
+			if field == nil {
+				return space, fmt.Errorf("podio-go: failed to import app %q: field %q was not created", bundleApp.Ref, bundleApp.Fields[n].ExternalID)
+			}
+			pending = append(pending, pendingField{app: app, field: *field, spec: bundleApp.Fields[n]})
+		}
+	}
+
+	for _, p := range pending {
+		config := p.field.Config
+		resolved := true
+		config.Settings = resolveReferences(p.spec.Config.Settings, created, &resolved)
+
+		_, err := c.UpdateFieldContext(ctx, strconv.Itoa(p.app.AppID), strconv.Itoa(p.field.FieldID), config)
+		if err != nil {
+			return space, fmt.Errorf("podio-go: failed to set references of field %q: %w", p.spec.ExternalID, err)
+		}
+	}
+
+	return space, nil
+}
+
+// bundleOrder orders the apps of bundle so that apps come after the apps
+// they reference. Apps in reference cycles keep their bundle order.
+func bundleOrder(bundle *SpaceBundle) ([]BundleApp, error) {
+	deps := map[string]map[string]bool{}
+	for _, app := range bundle.Apps {
+		if _, ok := deps[app.Ref]; ok || app.Ref == "" {
+			return nil, fmt.Errorf("podio-go: invalid bundle: app refs must be unique and not empty, got %q", app.Ref)
+		}
+		deps[app.Ref] = map[string]bool{}
+	}
+
+	for _, app := range bundle.Apps {
+		for _, field := range app.Fields {
+			if field.Type != FieldTypeApp {
+				continue
+			}
+			mapReferencedApps(field.Config.Settings, func(entry map[string]interface{}) bool {
+				if ref, ok := entry["app_ref"].(string); ok && ref != app.Ref {
+					if _, ok := deps[ref]; ok {
+						deps[app.Ref][ref] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	var order []BundleApp
+	done := map[string]bool{}
+
+	for len(order) < len(bundle.Apps) {
+		progressed := false
+		for _, app := range bundle.Apps {
+			if done[app.Ref] || !allDone(deps[app.Ref], done) {
+				continue
+			}
+			order = append(order, app)
+			done[app.Ref] = true
+			progressed = true
+		}
+
+		if !progressed {
+			// Break a cycle with the first remaining app; its references
+			// are fixed up after every app is created.
+			for _, app := range bundle.Apps {
+				if !done[app.Ref] {
+					order = append(order, app)
+					done[app.Ref] = true
+					break
+				}
+			}
+		}
+	}
+
+	return order, nil
+}
+
+func allDone(refs map[string]bool, done map[string]bool) bool {
+	for ref := range refs {
+		if !done[ref] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolveReferences replaces the app refs in the settings of an app
+// reference field with the ids of created apps. References to apps not
+// created yet are dropped, and resolved is set to false.
+func resolveReferences(settings interface{}, created map[string]*Application, resolved *bool) interface{} {
+	return mapReferencedApps(settings, func(entry map[string]interface{}) bool {
+		ref, ok := entry["app_ref"].(string)
+		if !ok {
+			return true
+		}
+
+		app, ok := created[ref]
+		if !ok {
+			*resolved = false
+			return false
+		}
+
+		delete(entry, "app_ref")
+		entry["app_id"] = app.AppID
+		return true
+	})
+}
+
+// mapReferencedApps calls fn with a copy of each entry of the
+// referenced_apps setting of an app reference field, keeping the entries fn
+// returns true for.
+func mapReferencedApps(settings interface{}, fn func(entry map[string]interface{}) bool) interface{} {
+	decoded, ok := generic(settings).(map[string]interface{})
+	if !ok {
+		return settings
+	}
+
+	entries, ok := decoded["referenced_apps"].([]interface{})
+	if !ok {
+		return decoded
+	}
+
+	kept := []interface{}{}
+	for _, entry := range entries {
+		entry, ok := entry.(map[string]interface{})
+		if ok && !fn(entry) {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+
+	decoded["referenced_apps"] = kept
+	return decoded
+}
+
+// createdField finds the field created for field of a bundle.
+func createdField(app *Application, field Field) *Field {
+	if found := app.Field(field.ExternalID); found != nil && field.ExternalID != "" {
+		return found
+	}
+
+	for n := range app.Fields {
+		if app.Fields[n].Type == field.Type && app.Fields[n].Config.Label == field.Config.Label {
+			return &app.Fields[n]
+		}
+	}
+
+	return nil
+}
+
+// bundleRef turns an app name into a ref, e.g. "Sales Leads" into
+// "sales-leads".
+func bundleRef(name string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			dash = true
+			continue
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+		dash = false
+	}
+
+	return b.String()
+}
@@ -0,0 +1,302 @@
+package podio_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func TestExportSpaceIsPortable(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	srv.AddApplication(podio.Application{
+		SpaceID: space.ID,
+		Config: podio.AppConfig{
+			Name:  "Deals",
+			Tasks: []podio.AppTask{{Text: "Follow up", Responsible: []podio.User{{UserID: 7, Name: "Ada"}}}},
+		},
+		Fields: []podio.Field{
+			{ExternalID: "stage", Type: podio.FieldTypeCategory, Status: "active", Config: podio.FieldConfig{
+				Label: "Stage",
+				Settings: map[string]interface{}{"options": []interface{}{
+					map[string]interface{}{"id": 1, "status": "active", "text": "New"},
+					map[string]interface{}{"id": 2, "status": "deleted", "text": "Old"},
+				}},
+			}},
+		},
+	})
+
+	bundle, err := srv.NewClient().ExportSpace(strconv.Itoa(space.ID))
+	if err != nil {
+		t.Fatalf("ExportSpace: %v", err)
+	}
+	if len(bundle.Apps) != 1 || len(bundle.Apps[0].Fields) != 1 {
+		t.Fatalf("bundle = %+v", bundle)
+	}
+
+	app := bundle.Apps[0]
+	if tasks := app.Config.Tasks; len(tasks) != 1 || tasks[0].Text != "Follow up" || len(tasks[0].Responsible) != 0 {
+		t.Errorf("tasks = %+v, want the task without responsible users", tasks)
+	}
+
+	field := app.Fields[0]
+	if field.FieldID != 0 || field.Status != "" {
+		t.Errorf("field id %d and status %q, want neither", field.FieldID, field.Status)
+	}
+
+	options := field.CategoryOptions()
+	if len(options) != 1 || options[0].Text != "New" || options[0].ID != 0 || options[0].Status != "" {
+		t.Errorf("options = %+v, want New without id or status", options)
+	}
+}
+
+// referenceField returns an app reference field referring to bundle refs.
+func referenceField(label string, refs ...string) podio.Field {
+	var entries []interface{}
+	for _, ref := range refs {
+		entries = append(entries, map[string]interface{}{"app_ref": ref})
+	}
+
+	return podio.Field{
+		ExternalID: strings.ToLower(label),
+		Type:       podio.FieldTypeApp,
+		Config:     podio.FieldConfig{Label: label, Settings: map[string]interface{}{"referenced_apps": entries}},
+	}
+}
+
+// referencedApps returns the ids of the apps a field refers to.
+func referencedApps(field *podio.Field) []int {
+	settings, _ := field.Config.Settings.(map[string]interface{})
+	entries, _ := settings["referenced_apps"].([]interface{})
+
+	var ids []int
+	for _, entry := range entries {
+		entry, _ := entry.(map[string]interface{})
+		id, _ := entry["app_id"].(float64)
+		ids = append(ids, int(id))
+	}
+	return ids
+}
+
+// importedApps returns the apps of a space by name.
+func importedApps(t *testing.T, srv *podiotest.Server, spaceID int) map[string]*podio.Application {
+	t.Helper()
+
+	client := srv.NewClient()
+	listed, err := client.GetApplications(strconv.Itoa(spaceID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apps := map[string]*podio.Application{}
+	for _, app := range *listed {
+		apps[app.Config.Name], _ = srv.Application(app.AppID)
+	}
+	return apps
+}
+
+func TestImportSpaceOrdersAndResolvesReferences(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+	bundle := &podio.SpaceBundle{
+		Space: podio.Space{Name: "Sales"},
+		Apps: []podio.BundleApp{
+			{Ref: "deals", Config: podio.AppConfig{Name: "Deals"}, Fields: []podio.Field{referenceField("Customer", "contacts")}},
+			{Ref: "contacts", Config: podio.AppConfig{Name: "Contacts"}, Fields: []podio.Field{{ExternalID: "name", Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Name"}}}},
+		},
+	}
+
+	space, err := srv.NewClient().ImportSpace(strconv.Itoa(org.ID), bundle)
+	if err != nil {
+		t.Fatalf("ImportSpace: %v", err)
+	}
+
+	apps := importedApps(t, srv, space.ID)
+	deals, contacts := apps["Deals"], apps["Contacts"]
+	if deals == nil || contacts == nil {
+		t.Fatalf("imported apps = %v", apps)
+	}
+	if contacts.AppID > deals.AppID {
+		t.Errorf("Contacts (%d) was created after Deals (%d), which refers to it", contacts.AppID, deals.AppID)
+	}
+	if ids := referencedApps(deals.Field("customer")); len(ids) != 1 || ids[0] != contacts.AppID {
+		t.Errorf("Deals refers to %v, want [%d]", ids, contacts.AppID)
+	}
+
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPut {
+			t.Errorf("references were fixed up without a cycle: %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestImportSpaceCycle(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+	bundle := &podio.SpaceBundle{
+		Space: podio.Space{Name: "Projects"},
+		Apps: []podio.BundleApp{
+			{Ref: "projects", Config: podio.AppConfig{Name: "Projects"}, Fields: []podio.Field{referenceField("Tasks", "tasks"), referenceField("Parent", "projects")}},
+			{Ref: "tasks", Config: podio.AppConfig{Name: "Tasks"}, Fields: []podio.Field{referenceField("Project", "projects")}},
+		},
+	}
+
+	space, err := srv.NewClient().ImportSpace(strconv.Itoa(org.ID), bundle)
+	if err != nil {
+		t.Fatalf("ImportSpace: %v", err)
+	}
+
+	apps := importedApps(t, srv, space.ID)
+	projects, tasks := apps["Projects"], apps["Tasks"]
+	if projects == nil || tasks == nil {
+		t.Fatalf("imported apps = %v", apps)
+	}
+
+	tests := []struct {
+		app   *podio.Application
+		field string
+		want  int
+	}{
+		{projects, "tasks", tasks.AppID},
+		{projects, "parent", projects.AppID},
+		{tasks, "project", projects.AppID},
+	}
+	for _, tt := range tests {
+		if ids := referencedApps(tt.app.Field(tt.field)); len(ids) != 1 || ids[0] != tt.want {
+			t.Errorf("%s.%s refers to %v, want [%d]", tt.app.Config.Name, tt.field, ids, tt.want)
+		}
+	}
+}
+
+func TestImportSpacePartialFailure(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+	bundle := &podio.SpaceBundle{
+		Space: podio.Space{Name: "Projects"},
+		Apps: []podio.BundleApp{
+			{Ref: "projects", Config: podio.AppConfig{Name: "Projects"}, Fields: []podio.Field{referenceField("Tasks", "tasks")}},
+			{Ref: "tasks", Config: podio.AppConfig{Name: "Tasks"}, Fields: []podio.Field{referenceField("Project", "projects")}},
+		},
+	}
+
+	client := srv.NewClient()
+	srv.Inject(podiotest.InternalError(http.MethodPut, "/app/"))
+
+	space, err := client.ImportSpace(strconv.Itoa(org.ID), bundle)
+	if err == nil || !strings.Contains(err.Error(), `failed to set references of field "tasks"`) {
+		t.Fatalf("err = %v, want the failed fix-up", err)
+	}
+	if space == nil {
+		t.Fatal("no space returned for the partial import")
+	}
+
+	apps := importedApps(t, srv, space.ID)
+	if len(apps) != 2 {
+		t.Fatalf("imported apps = %v, want both apps left in place", apps)
+	}
+	if ids := referencedApps(apps["Projects"].Field("tasks")); len(ids) != 0 {
+		t.Errorf("Projects refers to %v, want no references before the fix-up", ids)
+	}
+}
+
+func TestImportSpaceAppFailure(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+	bundle := &podio.SpaceBundle{
+		Space: podio.Space{Name: "Sales"},
+		Apps:  []podio.BundleApp{{Ref: "deals", Config: podio.AppConfig{Name: "Deals"}}},
+	}
+
+	client := srv.NewClient()
+	srv.Inject(podiotest.InternalError(http.MethodPost, "/app"))
+
+	space, err := client.ImportSpace(strconv.Itoa(org.ID), bundle)
+	if err == nil || !strings.Contains(err.Error(), `failed to import app "deals"`) {
+		t.Fatalf("err = %v, want the failed app", err)
+	}
+	if space == nil {
+		t.Fatal("no space returned for the partial import")
+	}
+	if _, ok := srv.Space(space.ID); !ok {
+		t.Error("the returned space doesn't exist")
+	}
+}
+
+func TestImportSpaceInvalidBundle(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	bundle := &podio.SpaceBundle{Apps: []podio.BundleApp{{Ref: "deals"}, {Ref: "deals"}}}
+
+	space, err := srv.NewClient().ImportSpace("1", bundle)
+	if err == nil || space != nil {
+		t.Errorf("ImportSpace = %v, %v, want an error and no space", space, err)
+	}
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path != "/oauth/token" {
+			t.Errorf("invalid bundle was imported: %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestExportSpaceRewritesReferences(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	outside := srv.AddApplication(podio.Application{SpaceID: space.ID + 1000, Config: podio.AppConfig{Name: "Elsewhere"}})
+	contacts := srv.AddApplication(podio.Application{SpaceID: space.ID, Config: podio.AppConfig{Name: "Contacts"}})
+	srv.AddApplication(podio.Application{
+		SpaceID: space.ID,
+		Config:  podio.AppConfig{Name: "Deals"},
+		Fields: []podio.Field{{
+			ExternalID: "customer",
+			Type:       podio.FieldTypeApp,
+			Config: podio.FieldConfig{Label: "Customer", Settings: map[string]interface{}{"referenced_apps": []interface{}{
+				map[string]interface{}{"app_id": contacts.AppID},
+				map[string]interface{}{"app_id": outside.AppID},
+			}}},
+		}},
+	})
+
+	bundle, err := srv.NewClient().ExportSpace(strconv.Itoa(space.ID))
+	if err != nil {
+		t.Fatalf("ExportSpace: %v", err)
+	}
+
+	var deals *podio.BundleApp
+	for n := range bundle.Apps {
+		if bundle.Apps[n].Ref == "deals" {
+			deals = &bundle.Apps[n]
+		}
+	}
+	if len(bundle.Apps) != 2 || deals == nil {
+		t.Fatalf("bundle apps = %+v, want contacts and deals", bundle.Apps)
+	}
+
+	settings, _ := deals.Fields[0].Config.Settings.(map[string]interface{})
+	entries, _ := settings["referenced_apps"].([]interface{})
+	if len(entries) != 2 {
+		t.Fatalf("referenced_apps = %v", settings["referenced_apps"])
+	}
+	if entry := entries[0].(map[string]interface{}); entry["app_ref"] != "contacts" || entry["app_id"] != nil {
+		t.Errorf("reference inside the space = %v, want app_ref contacts", entry)
+	}
+	if entry := entries[1].(map[string]interface{}); entry["app_id"] != float64(outside.AppID) {
+		t.Errorf("reference outside the space = %v, want app_id %d", entry, outside.AppID)
+	}
+}
@@ -1,12 +1,9 @@
 package podio
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
 	"strconv"
 )
 
@@ -17,6 +14,8 @@ type Field struct {
 	Type string `json:"type,omitempty"`
 	// "external_id": External id automatically generated that will never change,
 	ExternalID string `json:"external_id,omitempty"`
+	// "status": The status of the field, either "active" or "deleted",
+	Status string `json:"status,omitempty"`
 	// "config": The configuration of the field,
 	Config FieldConfig `json:"config,omitempty"`
 }
@@ -36,6 +35,55 @@ type FieldConfig struct {
 	Required bool `json:"required,omitempty"`
 }
 
+type CategoryOption struct {
+	// "id": The id of the option,
+	ID int `json:"id,omitempty"`
+	// "text": The text of the option,
+	Text string `json:"text,omitempty"`
+	// "status": The status of the option, either "active" or "deleted",
+	Status string `json:"status,omitempty"`
+	// "color": The color of the option, as a hex code,
+	Color string `json:"color,omitempty"`
+}
+
+// CategoryOptions returns the options of a category field, from its settings.
+func (f *Field) CategoryOptions() []CategoryOption {
+	settings := struct {
+		Options []CategoryOption `json:"options"`
+	}{}
+	f.decodeSettings(&settings)
+	return settings.Options
+}
+
+// defaultCurrency returns the first currency allowed by a money field.
+func (f *Field) defaultCurrency() string {
+	settings := struct {
+		AllowedCurrencies []string `json:"allowed_currencies"`
+	}{}
+	f.decodeSettings(&settings)
+
+	if len(settings.AllowedCurrencies) == 0 {
+		return ""
+	}
+
+	return settings.AllowedCurrencies[0]
+}
+
+// decodeSettings decodes the settings of the field, which are a generic map
+// when read from Podio, into v.
+func (f *Field) decodeSettings(v interface{}) {
+	if f.Config.Settings == nil {
+		return
+	}
+
+	data, err := json.Marshal(f.Config.Settings)
+	if err != nil {
+		return
+	}
+
+	json.Unmarshal(data, v)
+}
+
 type FieldDelete struct {
 	// "field_id": The id of the field,
 	FieldID int `json:"field_id,omitempty"`
@@ -49,79 +97,53 @@ type CreateFieldParams struct {
 }
 
 func (c *Client) CreateField(appID string, params CreateFieldParams) (*Field, error) {
-	var err error
-
-	body := &bytes.Buffer{}
-	err = json.NewEncoder(body).Encode(params)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: could not encode params: %w", err)
-	}
-
-	resp, err := c.httpClient.Post(fmt.Sprintf("/app/%s/field/", appID), "application/json", body)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to create application: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("podio-go: failed to create field: %s\nPayload: %s", resp.Status, string(output))
-	}
+	return c.CreateFieldContext(context.Background(), appID, params)
+}
 
+func (c *Client) CreateFieldContext(ctx context.Context, appID string, params CreateFieldParams) (*Field, error) {
 	//This line defines a struct type and simultaneously creates an empty struct
 	data := &struct {
 		FieldID int `json:"field_id"`
 	}{}
-	err = json.NewDecoder(resp.Body).Decode(data)
+	err := c.post(ctx, fmt.Sprintf("/app/%s/field/", appID), params, data)
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to decode field creation response: %w", err)
+		return nil, fmt.Errorf("podio-go: failed to create field: %w", err)
 	}
 
-	return c.GetField(appID, strconv.Itoa(data.FieldID))
+	return c.GetFieldContext(ctx, appID, strconv.Itoa(data.FieldID))
 }
 
 func (c *Client) GetField(appID string, fieldID string) (*Field, error) {
+	return c.GetFieldContext(context.Background(), appID, fieldID)
+}
+
+func (c *Client) GetFieldContext(ctx context.Context, appID string, fieldID string) (*Field, error) {
 	field := &Field{}
-	err := c.get(fmt.Sprintf("/app/%s/field/%s", appID, fieldID), field)
+	err := c.get(ctx, fmt.Sprintf("/app/%s/field/%s", appID, fieldID), field)
 	return field, err
 }
 
 func (c *Client) UpdateField(appID string, fieldID string, params FieldConfig) (*Field, error) {
-	var err error
-
-	body := &bytes.Buffer{}
-	err = json.NewEncoder(body).Encode(params)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: could not encode params: %w", err)
-	}
+	return c.UpdateFieldContext(context.Background(), appID, fieldID, params)
+}
 
-	url, err := url.Parse(fmt.Sprintf("/app/%s/field/%s/", appID, fieldID))
+func (c *Client) UpdateFieldContext(ctx context.Context, appID string, fieldID string, params FieldConfig) (*Field, error) {
+	err := c.put(ctx, fmt.Sprintf("/app/%s/field/%s/", appID, fieldID), params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to generate url: %w", err)
-	}
-
-	req := &http.Request{
-		Method: "PUT",
-		URL:    url,
-		Body:   ioutil.NopCloser(body),
+		return nil, fmt.Errorf("podio-go: failed to update field: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to create field: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("podio-go: failed to update field: %s\nPayload: %s", resp.Status, string(output))
-	}
-
-	return c.GetField(appID, fieldID)
+	return c.GetFieldContext(ctx, appID, fieldID)
 }
 
 func (c *Client) DeleteField(appID string, fieldID string, deleteValues bool) error {
+	return c.DeleteFieldContext(context.Background(), appID, fieldID, deleteValues)
+}
+
+func (c *Client) DeleteFieldContext(ctx context.Context, appID string, fieldID string, deleteValues bool) error {
 	deleteValuesStr := "false"
 	if deleteValues {
 		deleteValuesStr = "true"
 	}
-	return c.delete(fmt.Sprintf("/app/%s/field/%s?deleteValues=%s", appID, fieldID, deleteValuesStr))
+	return c.delete(ctx, fmt.Sprintf("/app/%s/field/%s?deleteValues=%s", appID, fieldID, deleteValuesStr))
 }
@@ -0,0 +1,690 @@
+package podio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Field types, as found in Field.Type and ItemField.Type.
+const (
+	FieldTypeText        = "text"
+	FieldTypeNumber      = "number"
+	FieldTypeMoney       = "money"
+	FieldTypeDate        = "date"
+	FieldTypeCategory    = "category"
+	FieldTypeApp         = "app"
+	FieldTypeContact     = "contact"
+	FieldTypeCalculation = "calculation"
+	FieldTypeLocation    = "location"
+	FieldTypeDuration    = "duration"
+	FieldTypeProgress    = "progress"
+	FieldTypeEmail       = "email"
+	FieldTypePhone       = "phone"
+	FieldTypeLink        = "embed"
+	FieldTypeImage       = "image"
+	FieldTypeTel         = "tel"
+)
+
+// FieldValue is a single value of an item field. Values decode from the
+// format Podio returns them in, and encode to the format Podio expects when
+// creating or updating items, so they can be used in ItemFields directly.
+type FieldValue interface {
+	FieldType() string
+}
+
+// NewFieldValue returns a pointer to an empty value for the given field type,
+// or a *RawValue for field types this package doesn't know.
+func NewFieldValue(fieldType string) FieldValue {
+	switch fieldType {
+	case FieldTypeText:
+		return &TextValue{}
+	case FieldTypeNumber:
+		return &NumberValue{}
+	case FieldTypeMoney:
+		return &MoneyValue{}
+	case FieldTypeDate:
+		return &DateValue{}
+	case FieldTypeCategory:
+		return &CategoryValue{}
+	case FieldTypeApp:
+		return &AppReferenceValue{}
+	case FieldTypeContact:
+		return &ContactValue{}
+	case FieldTypeCalculation:
+		return &CalculationValue{}
+	case FieldTypeLocation:
+		return &LocationValue{}
+	case FieldTypeDuration:
+		return &DurationValue{}
+	case FieldTypeProgress:
+		return &ProgressValue{}
+	case FieldTypeEmail:
+		return &EmailValue{}
+	case FieldTypePhone:
+		return &PhoneValue{}
+	case FieldTypeLink:
+		return &LinkValue{}
+	case FieldTypeImage:
+		return &ImageValue{}
+	case FieldTypeTel:
+		return &TelValue{}
+	}
+
+	return &RawValue{Type: fieldType}
+}
+
+// DecodeFieldValues decodes the values of a field of the given type. The
+// values are pointers, e.g. *TextValue for text fields.
+func DecodeFieldValues(fieldType string, raw json.RawMessage) ([]FieldValue, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var rawValues []json.RawMessage
+	if err := json.Unmarshal(raw, &rawValues); err != nil {
+		return nil, fmt.Errorf("podio-go: failed to decode %s values: %w", fieldType, err)
+	}
+
+	values := make([]FieldValue, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		value := NewFieldValue(fieldType)
+		if err := json.Unmarshal(rawValue, value); err != nil {
+			return nil, fmt.Errorf("podio-go: failed to decode %s value: %w", fieldType, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// DecodeValues decodes the values of the field according to its type.
+func (f *ItemField) DecodeValues() ([]FieldValue, error) {
+	return DecodeFieldValues(f.Type, f.Values)
+}
+
+// RawValue is a value of a field type this package doesn't know. It is
+// encoded exactly as it was decoded.
+type RawValue struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (v RawValue) FieldType() string { return v.Type }
+
+func (v RawValue) MarshalJSON() ([]byte, error) {
+	if len(v.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return v.Raw, nil
+}
+
+func (v *RawValue) UnmarshalJSON(data []byte) error {
+	v.Raw = append(v.Raw[:0], data...)
+	return nil
+}
+
+type TextValue struct {
+	Value string
+}
+
+func (v TextValue) FieldType() string { return FieldTypeText }
+
+func (v TextValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *TextValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value string `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return err
+	}
+
+	v.Value = wire.Value
+	return nil
+}
+
+type NumberValue struct {
+	Value float64
+}
+
+func (v NumberValue) FieldType() string { return FieldTypeNumber }
+
+func (v NumberValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *NumberValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value flexibleNumber `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return err
+	}
+
+	v.Value = float64(wire.Value)
+	return nil
+}
+
+type MoneyValue struct {
+	Value    float64
+	Currency string
+}
+
+func (v MoneyValue) FieldType() string { return FieldTypeMoney }
+
+func (v MoneyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Value    string `json:"value"`
+		Currency string `json:"currency"`
+	}{strconv.FormatFloat(v.Value, 'f', -1, 64), v.Currency})
+}
+
+func (v *MoneyValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value    flexibleNumber `json:"value"`
+		Currency string         `json:"currency"`
+	}{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	v.Value = float64(wire.Value)
+	v.Currency = wire.Currency
+	return nil
+}
+
+// podioDateTime and podioDate are the layouts of dates and times in Podio.
+// They carry no time zone and are in the time zone of the user.
+const (
+	podioDateTime = "2006-01-02 15:04:05"
+	podioDate     = "2006-01-02"
+	podioTime     = "15:04:05"
+)
+
+// DateValue is the value of a date field. Start and End are in UTC, but
+// reflect the local date and time of the user, as Podio stores them.
+type DateValue struct {
+	Start time.Time
+	// End is zero for dates without an end.
+	End time.Time
+	// HasTime is false for dates without a time of day.
+	HasTime bool
+}
+
+func (v DateValue) FieldType() string { return FieldTypeDate }
+
+func (v DateValue) MarshalJSON() ([]byte, error) {
+	wire := map[string]interface{}{
+		"start_date": v.Start.Format(podioDate),
+	}
+
+	if v.HasTime {
+		wire["start_time"] = v.Start.Format(podioTime)
+	}
+
+	if !v.End.IsZero() {
+		wire["end_date"] = v.End.Format(podioDate)
+		if v.HasTime {
+			wire["end_time"] = v.End.Format(podioTime)
+		}
+	}
+
+	return json.Marshal(wire)
+}
+
+func (v *DateValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		StartDate string `json:"start_date"`
+		StartTime string `json:"start_time"`
+		EndDate   string `json:"end_date"`
+		EndTime   string `json:"end_time"`
+	}{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	var err error
+	*v = DateValue{HasTime: wire.StartTime != ""}
+
+	v.Start, err = parsePodioDate(wire.StartDate, wire.StartTime)
+	if err != nil {
+		return err
+	}
+
+	if wire.EndDate != "" {
+		v.End, err = parsePodioDate(wire.EndDate, wire.EndTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parsePodioDate(date, clock string) (time.Time, error) {
+	if clock == "" {
+		return time.Parse(podioDate, date)
+	}
+
+	return time.Parse(podioDateTime, date+" "+clock)
+}
+
+// CategoryValue is a selected option of a category field. Only ID is used
+// when creating or updating items.
+type CategoryValue struct {
+	ID     int
+	Text   string
+	Color  string
+	Status string
+}
+
+func (v CategoryValue) FieldType() string { return FieldTypeCategory }
+
+func (v CategoryValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ID)
+}
+
+func (v *CategoryValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value struct {
+			ID     int    `json:"id"`
+			Text   string `json:"text"`
+			Color  string `json:"color"`
+			Status string `json:"status"`
+		} `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value.ID); err != nil {
+		return err
+	}
+
+	*v = CategoryValue(wire.Value)
+	return nil
+}
+
+// AppReferenceValue is a referenced item of an app field. Only ItemID is used
+// when creating or updating items.
+type AppReferenceValue struct {
+	ItemID int
+	AppID  int
+	Title  string
+	Link   string
+}
+
+func (v AppReferenceValue) FieldType() string { return FieldTypeApp }
+
+func (v AppReferenceValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ItemID)
+}
+
+func (v *AppReferenceValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value struct {
+			ItemID int    `json:"item_id"`
+			Title  string `json:"title"`
+			Link   string `json:"link"`
+			App    struct {
+				AppID int `json:"app_id"`
+			} `json:"app"`
+		} `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value.ItemID); err != nil {
+		return err
+	}
+
+	*v = AppReferenceValue{
+		ItemID: wire.Value.ItemID,
+		AppID:  wire.Value.App.AppID,
+		Title:  wire.Value.Title,
+		Link:   wire.Value.Link,
+	}
+	return nil
+}
+
+// ContactValue is a referenced profile of a contact field. Only ProfileID is
+// used when creating or updating items.
+type ContactValue struct {
+	ProfileID int
+	UserID    int
+	Name      string
+	Type      string
+	Mail      []string
+	Phone     []string
+}
+
+func (v ContactValue) FieldType() string { return FieldTypeContact }
+
+func (v ContactValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.ProfileID)
+}
+
+func (v *ContactValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value struct {
+			ProfileID int      `json:"profile_id"`
+			UserID    int      `json:"user_id"`
+			Name      string   `json:"name"`
+			Type      string   `json:"type"`
+			Mail      []string `json:"mail"`
+			Phone     []string `json:"phone"`
+		} `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value.ProfileID); err != nil {
+		return err
+	}
+
+	*v = ContactValue(wire.Value)
+	return nil
+}
+
+// CalculationValue is the result of a calculation field. Calculations are
+// read only, so it can't be encoded.
+type CalculationValue struct {
+	// Value is the result as returned by Podio, a number, text or date.
+	Value string
+}
+
+func (v CalculationValue) FieldType() string { return FieldTypeCalculation }
+
+func (v CalculationValue) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("podio-go: calculation fields are read only")
+}
+
+func (v *CalculationValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value json.RawMessage `json:"value"`
+	}{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	var text string
+	if json.Unmarshal(wire.Value, &text) == nil {
+		v.Value = text
+	} else {
+		v.Value = string(wire.Value)
+	}
+	return nil
+}
+
+// LocationValue is the value of a location field. Only Value, the address as
+// entered, is used when creating or updating items.
+type LocationValue struct {
+	Value        string
+	Formatted    string
+	StreetNumber string
+	StreetName   string
+	PostalCode   string
+	City         string
+	State        string
+	Country      string
+	Lat          float64
+	Lng          float64
+}
+
+func (v LocationValue) FieldType() string { return FieldTypeLocation }
+
+func (v LocationValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *LocationValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value        string  `json:"value"`
+		Formatted    string  `json:"formatted"`
+		StreetNumber string  `json:"street_number"`
+		StreetName   string  `json:"street_name"`
+		PostalCode   string  `json:"postal_code"`
+		City         string  `json:"city"`
+		State        string  `json:"state"`
+		Country      string  `json:"country"`
+		Lat          float64 `json:"lat"`
+		Lng          float64 `json:"lng"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return err
+	}
+
+	*v = LocationValue(wire)
+	return nil
+}
+
+type DurationValue struct {
+	Value time.Duration
+}
+
+func (v DurationValue) FieldType() string { return FieldTypeDuration }
+
+func (v DurationValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(v.Value / time.Second))
+}
+
+func (v *DurationValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value flexibleNumber `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return err
+	}
+
+	v.Value = time.Duration(wire.Value) * time.Second
+	return nil
+}
+
+// ProgressValue is the value of a progress field, from 0 to 100.
+type ProgressValue struct {
+	Value int
+}
+
+func (v ProgressValue) FieldType() string { return FieldTypeProgress }
+
+func (v ProgressValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Value)
+}
+
+func (v *ProgressValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value flexibleNumber `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return err
+	}
+
+	v.Value = int(wire.Value)
+	return nil
+}
+
+// EmailValue is an email address, with a type such as "work", "home" or "other".
+type EmailValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (v EmailValue) FieldType() string { return FieldTypeEmail }
+
+func (v *EmailValue) UnmarshalJSON(data []byte) error {
+	valueType, value, err := unmarshalTypedValue(data)
+	*v = EmailValue{Type: valueType, Value: value}
+	return err
+}
+
+// PhoneValue is a phone number, with a type such as "mobile", "work" or "home".
+type PhoneValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (v PhoneValue) FieldType() string { return FieldTypePhone }
+
+func (v *PhoneValue) UnmarshalJSON(data []byte) error {
+	valueType, value, err := unmarshalTypedValue(data)
+	*v = PhoneValue{Type: valueType, Value: value}
+	return err
+}
+
+// unmarshalTypedValue decodes an email or phone value, either an object with
+// a type and a value, or the plain value, which is of type "other".
+func unmarshalTypedValue(data []byte) (string, string, error) {
+	wire := struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}{Type: "other"}
+	if err := unmarshalValue(data, &wire, &wire.Value); err != nil {
+		return "", "", err
+	}
+
+	return wire.Type, wire.Value, nil
+}
+
+// LinkValue is a link of a link field, which Podio calls an embed. Either
+// EmbedID or URL is used when creating or updating items.
+type LinkValue struct {
+	EmbedID     int
+	URL         string
+	Title       string
+	Description string
+}
+
+func (v LinkValue) FieldType() string { return FieldTypeLink }
+
+func (v LinkValue) MarshalJSON() ([]byte, error) {
+	if v.EmbedID != 0 {
+		return json.Marshal(map[string]int{"embed": v.EmbedID})
+	}
+
+	return json.Marshal(map[string]string{"url": v.URL})
+}
+
+func (v *LinkValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Embed struct {
+			EmbedID     int    `json:"embed_id"`
+			URL         string `json:"url"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"embed"`
+	}{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	*v = LinkValue(wire.Embed)
+	return nil
+}
+
+// ImageValue is an image file of an image field. Only FileID is used when
+// creating or updating items.
+type ImageValue struct {
+	FileID        int
+	Name          string
+	MimeType      string
+	Link          string
+	ThumbnailLink string
+}
+
+func (v ImageValue) FieldType() string { return FieldTypeImage }
+
+func (v ImageValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.FileID)
+}
+
+func (v *ImageValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value struct {
+			FileID        int    `json:"file_id"`
+			Name          string `json:"name"`
+			MimeType      string `json:"mimetype"`
+			Link          string `json:"link"`
+			ThumbnailLink string `json:"thumbnail_link"`
+		} `json:"value"`
+	}{}
+	if err := unmarshalValue(data, &wire, &wire.Value.FileID); err != nil {
+		return err
+	}
+
+	*v = ImageValue(wire.Value)
+	return nil
+}
+
+// TelValue is a phone number of a tel field.
+type TelValue struct {
+	Number string
+	URI    string
+}
+
+func (v TelValue) FieldType() string { return FieldTypeTel }
+
+func (v TelValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"value": v.Number})
+}
+
+func (v *TelValue) UnmarshalJSON(data []byte) error {
+	wire := struct {
+		Value json.RawMessage `json:"value"`
+		URI   string          `json:"uri"`
+	}{}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	// The value is either the number itself, or an object with the number and URI.
+	*v = TelValue{URI: wire.URI}
+	if json.Unmarshal(wire.Value, &v.Number) == nil {
+		return nil
+	}
+
+	object := struct {
+		Number string `json:"number"`
+		URI    string `json:"uri"`
+	}{}
+	if err := json.Unmarshal(wire.Value, &object); err != nil {
+		return err
+	}
+
+	*v = TelValue(object)
+	return nil
+}
+
+// unmarshalValue decodes data into wire, the {"value": ...} format Podio
+// returns. Values in the plain format used when writing, as in ItemFields,
+// are decoded into plain instead.
+func unmarshalValue(data []byte, wire interface{}, plain interface{}) error {
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, wire)
+	}
+
+	return json.Unmarshal(data, plain)
+}
+
+// flexibleNumber decodes numbers Podio returns either as JSON numbers or as
+// strings, e.g. "12.5000".
+type flexibleNumber float64
+
+func (n *flexibleNumber) UnmarshalJSON(data []byte) error {
+	var text string
+	if json.Unmarshal(data, &text) == nil {
+		if text == "" {
+			*n = 0
+			return nil
+		}
+
+		parsed, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return err
+		}
+
+		*n = flexibleNumber(parsed)
+		return nil
+	}
+
+	var number float64
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+
+	*n = flexibleNumber(number)
+	return nil
+}
@@ -0,0 +1,178 @@
+package podio_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+func TestDecodeFieldValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType string
+		raw       string
+		want      podio.FieldValue
+	}{
+		{
+			"date without time", podio.FieldTypeDate,
+			`[{"start": "2024-03-01 00:00:00", "start_date": "2024-03-01", "start_time": null, "end": null, "end_date": null, "end_time": null}]`,
+			&podio.DateValue{Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			"date with time", podio.FieldTypeDate,
+			`[{"start": "2024-03-01 09:30:00", "start_date": "2024-03-01", "start_time": "09:30:00", "end": "2024-03-02 17:00:00", "end_date": "2024-03-02", "end_time": "17:00:00"}]`,
+			&podio.DateValue{Start: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), End: time.Date(2024, 3, 2, 17, 0, 0, 0, time.UTC), HasTime: true},
+		},
+		{
+			"money as a string", podio.FieldTypeMoney,
+			`[{"value": "1234.5000", "currency": "EUR"}]`,
+			&podio.MoneyValue{Value: 1234.5, Currency: "EUR"},
+		},
+		{
+			"number as a string", podio.FieldTypeNumber,
+			`[{"value": "12.5000"}]`,
+			&podio.NumberValue{Value: 12.5},
+		},
+		{
+			"number as a number", podio.FieldTypeNumber,
+			`[{"value": 12.5}]`,
+			&podio.NumberValue{Value: 12.5},
+		},
+		{
+			"plain number", podio.FieldTypeNumber,
+			`[12.5]`,
+			&podio.NumberValue{Value: 12.5},
+		},
+		{
+			"duration as a string", podio.FieldTypeDuration,
+			`[{"value": "5400"}]`,
+			&podio.DurationValue{Value: 90 * time.Minute},
+		},
+		{
+			"progress as a number", podio.FieldTypeProgress,
+			`[{"value": 40}]`,
+			&podio.ProgressValue{Value: 40},
+		},
+		{
+			"email with a type", podio.FieldTypeEmail,
+			`[{"type": "work", "value": "jane@example.com"}]`,
+			&podio.EmailValue{Type: "work", Value: "jane@example.com"},
+		},
+		{
+			"plain email", podio.FieldTypeEmail,
+			`["jane@example.com"]`,
+			&podio.EmailValue{Type: "other", Value: "jane@example.com"},
+		},
+		{
+			"phone with a type", podio.FieldTypePhone,
+			`[{"type": "mobile", "value": "+45 12 34 56 78"}]`,
+			&podio.PhoneValue{Type: "mobile", Value: "+45 12 34 56 78"},
+		},
+		{
+			"plain phone", podio.FieldTypePhone,
+			`["+45 12 34 56 78"]`,
+			&podio.PhoneValue{Type: "other", Value: "+45 12 34 56 78"},
+		},
+		{
+			"category", podio.FieldTypeCategory,
+			`[{"value": {"id": 2, "text": "Won", "color": "DCEBD8", "status": "active"}}]`,
+			&podio.CategoryValue{ID: 2, Text: "Won", Color: "DCEBD8", Status: "active"},
+		},
+		{
+			"numeric calculation", podio.FieldTypeCalculation,
+			`[{"value": "42.0000"}]`,
+			&podio.CalculationValue{Value: "42.0000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := podio.DecodeFieldValues(tt.fieldType, json.RawMessage(tt.raw))
+			if err != nil {
+				t.Fatalf("DecodeFieldValues: %v", err)
+			}
+			if len(values) != 1 || !reflect.DeepEqual(values[0], tt.want) {
+				t.Errorf("values = %#v, want %#v", values, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFieldValuesInvalid(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		raw       string
+	}{
+		{podio.FieldTypeNumber, `[{"value": "twelve"}]`},
+		{podio.FieldTypeDate, `[{"start_date": "01/03/2024"}]`},
+		{podio.FieldTypeText, `{"value": "not a list"}`},
+	}
+
+	for _, tt := range tests {
+		if values, err := podio.DecodeFieldValues(tt.fieldType, json.RawMessage(tt.raw)); err == nil {
+			t.Errorf("DecodeFieldValues(%s, %s) = %v, want an error", tt.fieldType, tt.raw, values)
+		}
+	}
+}
+
+func TestEncodeFieldValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value podio.FieldValue
+		want  string
+	}{
+		{
+			"date without time",
+			podio.DateValue{Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			`{"start_date":"2024-03-01"}`,
+		},
+		{
+			"date with time",
+			podio.DateValue{Start: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), End: time.Date(2024, 3, 2, 17, 0, 0, 0, time.UTC), HasTime: true},
+			`{"end_date":"2024-03-02","end_time":"17:00:00","start_date":"2024-03-01","start_time":"09:30:00"}`,
+		},
+		{
+			"money",
+			podio.MoneyValue{Value: 1234.5, Currency: "EUR"},
+			`{"value":"1234.5","currency":"EUR"}`,
+		},
+		{
+			"category",
+			podio.CategoryValue{ID: 2, Text: "Won"},
+			`2`,
+		},
+		{
+			"duration",
+			podio.DurationValue{Value: 90 * time.Minute},
+			`5400`,
+		},
+		{
+			"email",
+			podio.EmailValue{Type: "work", Value: "jane@example.com"},
+			`{"type":"work","value":"jane@example.com"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeCalculationValue(t *testing.T) {
+	_, err := json.Marshal(podio.CalculationValue{Value: "42"})
+	if err == nil || !strings.Contains(err.Error(), "read only") {
+		t.Errorf("err = %v, want calculation fields to be read only", err)
+	}
+}
@@ -0,0 +1,347 @@
+package podio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxFilterLimit is the largest page Podio returns when filtering items.
+const maxFilterLimit = 500
+
+// builtinFilterKeys are the filter keys that aren't fields of the app.
+var builtinFilterKeys = map[string]bool{
+	"created_on":   true,
+	"created_by":   true,
+	"last_edit_on": true,
+	"last_edit_by": true,
+	"title":        true,
+	"app_item_id":  true,
+	"external_id":  true,
+}
+
+// builtinSortKeys are the sort keys that aren't fields of the app.
+var builtinSortKeys = map[string]bool{
+	"created_on":   true,
+	"last_edit_on": true,
+	"title":        true,
+}
+
+// ItemFilter builds a query for FilterItems. Fields are referred to by
+// external id or field id. Filters on the same field replace each other.
+type ItemFilter struct {
+	filters  []itemFilterEntry
+	sortBy   string
+	sortDesc bool
+	limit    int
+	offset   int
+	problems []string
+}
+
+type itemFilterEntry struct {
+	key string
+	// fieldTypes are the field types the filter applies to, empty for built in keys.
+	fieldTypes []string
+	value      interface{}
+}
+
+// NewItemFilter returns a filter matching every item of the app.
+func NewItemFilter() *ItemFilter {
+	return &ItemFilter{}
+}
+
+func (f *ItemFilter) add(key string, value interface{}, fieldTypes ...string) *ItemFilter {
+	for n, entry := range f.filters {
+		if entry.key == key {
+			f.filters[n] = itemFilterEntry{key, fieldTypes, value}
+			return f
+		}
+	}
+
+	f.filters = append(f.filters, itemFilterEntry{key, fieldTypes, value})
+	return f
+}
+
+// Category matches items with any of the given options selected.
+func (f *ItemFilter) Category(field string, optionIDs ...int) *ItemFilter {
+	if len(optionIDs) == 0 {
+		f.problems = append(f.problems, fmt.Sprintf("category filter on %s needs at least one option", field))
+	}
+
+	return f.add(field, optionIDs, FieldTypeCategory)
+}
+
+// AppReference matches items referencing any of the given items.
+func (f *ItemFilter) AppReference(field string, itemIDs ...int) *ItemFilter {
+	if len(itemIDs) == 0 {
+		f.problems = append(f.problems, fmt.Sprintf("app reference filter on %s needs at least one item", field))
+	}
+
+	return f.add(field, itemIDs, FieldTypeApp)
+}
+
+// Contact matches items referencing any of the given profiles.
+func (f *ItemFilter) Contact(field string, profileIDs ...int) *ItemFilter {
+	if len(profileIDs) == 0 {
+		f.problems = append(f.problems, fmt.Sprintf("contact filter on %s needs at least one profile", field))
+	}
+
+	return f.add(field, profileIDs, FieldTypeContact)
+}
+
+// DateRange matches items with a date between from and to, inclusive. A zero
+// from or to leaves that end of the range open.
+func (f *ItemFilter) DateRange(field string, from, to time.Time) *ItemFilter {
+	return f.add(field, newDateRange(from, to), FieldTypeDate, FieldTypeCalculation)
+}
+
+// NumberRange matches items with a value between from and to, inclusive.
+func (f *ItemFilter) NumberRange(field string, from, to float64) *ItemFilter {
+	if from > to {
+		f.problems = append(f.problems, fmt.Sprintf("number filter on %s has from greater than to", field))
+	}
+
+	return f.add(field, map[string]float64{"from": from, "to": to}, numericFieldTypes...)
+}
+
+// NumberFrom matches items with a value of at least from.
+func (f *ItemFilter) NumberFrom(field string, from float64) *ItemFilter {
+	return f.add(field, map[string]float64{"from": from}, numericFieldTypes...)
+}
+
+// NumberTo matches items with a value of at most to.
+func (f *ItemFilter) NumberTo(field string, to float64) *ItemFilter {
+	return f.add(field, map[string]float64{"to": to}, numericFieldTypes...)
+}
+
+var numericFieldTypes = []string{FieldTypeNumber, FieldTypeMoney, FieldTypeProgress, FieldTypeDuration, FieldTypeCalculation}
+
+// CreatedBy matches items created by any of the given users.
+func (f *ItemFilter) CreatedBy(userIDs ...int) *ItemFilter {
+	if len(userIDs) == 0 {
+		f.problems = append(f.problems, "created_by filter needs at least one user")
+	}
+
+	refs := make([]map[string]interface{}, 0, len(userIDs))
+	for _, id := range userIDs {
+		refs = append(refs, map[string]interface{}{"type": "user", "id": id})
+	}
+
+	return f.add("created_by", refs)
+}
+
+// CreatedOn matches items created between from and to, see DateRange.
+func (f *ItemFilter) CreatedOn(from, to time.Time) *ItemFilter {
+	return f.add("created_on", newDateRange(from, to))
+}
+
+// SortBy sorts the items by a field, or one of "created_on", "last_edit_on"
+// and "title".
+func (f *ItemFilter) SortBy(field string, desc bool) *ItemFilter {
+	f.sortBy = field
+	f.sortDesc = desc
+	return f
+}
+
+// Limit sets the number of items per page, at most 500. Zero leaves it to
+// Podio's default.
+func (f *ItemFilter) Limit(limit int) *ItemFilter {
+	f.limit = limit
+	return f
+}
+
+// Offset sets the number of items to skip.
+func (f *ItemFilter) Offset(offset int) *ItemFilter {
+	f.offset = offset
+	return f
+}
+
+func newDateRange(from, to time.Time) map[string]string {
+	dates := map[string]string{}
+	if !from.IsZero() {
+		dates["from"] = from.Format(podioDateTime)
+	}
+	if !to.IsZero() {
+		dates["to"] = to.Format(podioDateTime)
+	}
+	return dates
+}
+
+// Validate checks the filter, and that every field it refers to exists on
+// the app with a type the filter applies to.
+func (f *ItemFilter) Validate(app *Application) error {
+	problems := append([]string{}, f.problems...)
+
+	if f.limit < 0 || f.limit > maxFilterLimit {
+		problems = append(problems, fmt.Sprintf("limit must be between 0 and %d, 0 being Podio's default", maxFilterLimit))
+	}
+
+	if app != nil {
+		for _, entry := range f.filters {
+			if len(entry.fieldTypes) == 0 {
+				continue
+			}
+
+			field := app.Field(entry.key)
+			if field == nil {
+				problems = append(problems, fmt.Sprintf("app %d has no field %s", app.AppID, entry.key))
+				continue
+			}
+
+			if !containsString(entry.fieldTypes, field.Type) {
+				problems = append(problems, fmt.Sprintf("field %s is a %s field, filter applies to %s", entry.key, field.Type, strings.Join(entry.fieldTypes, ", ")))
+			}
+		}
+
+		if f.sortBy != "" && !builtinSortKeys[f.sortBy] && !builtinFilterKeys[f.sortBy] && app.Field(f.sortBy) == nil {
+			problems = append(problems, fmt.Sprintf("app %d has no field %s to sort by", app.AppID, f.sortBy))
+		}
+	}
+
+	if builtinFilterKeys[f.sortBy] && !builtinSortKeys[f.sortBy] {
+		problems = append(problems, fmt.Sprintf("items can't be sorted by %s", f.sortBy))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("podio-go: invalid item filter: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// refersToFields reports whether the filter filters or sorts by fields of
+// the app.
+func (f *ItemFilter) refersToFields() bool {
+	for _, entry := range f.filters {
+		if len(entry.fieldTypes) > 0 {
+			return true
+		}
+	}
+
+	return f.sortBy != "" && !builtinSortKeys[f.sortBy] && !builtinFilterKeys[f.sortBy]
+}
+
+func (f *ItemFilter) MarshalJSON() ([]byte, error) {
+	filters := map[string]interface{}{}
+	for _, entry := range f.filters {
+		filters[entry.key] = entry.value
+	}
+
+	body := map[string]interface{}{
+		"filters":  filters,
+		"remember": false,
+	}
+
+	if f.sortBy != "" {
+		body["sort_by"] = f.sortBy
+		body["sort_desc"] = f.sortDesc
+	}
+
+	if f.limit > 0 {
+		body["limit"] = f.limit
+	}
+
+	if f.offset > 0 {
+		body["offset"] = f.offset
+	}
+
+	return json.Marshal(body)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+type FilterItemsResult struct {
+	// "total": The total number of items in the app,
+	Total int `json:"total"`
+	// "filtered": The number of items matching the filter,
+	Filtered int `json:"filtered"`
+	// "items": The page of matching items,
+	Items []Item `json:"items"`
+}
+
+// FilterItems returns a page of the items matching filter. Filters that
+// refer to fields of the app are validated against it, which fetches the app
+// first.
+func (c *Client) FilterItems(appID string, filter *ItemFilter) (*FilterItemsResult, error) {
+	return c.FilterItemsContext(context.Background(), appID, filter)
+}
+
+func (c *Client) FilterItemsContext(ctx context.Context, appID string, filter *ItemFilter) (*FilterItemsResult, error) {
+	if filter == nil {
+		filter = NewItemFilter()
+	}
+
+	if err := c.validateFilter(ctx, appID, filter); err != nil {
+		return nil, err
+	}
+
+	return c.filterItems(ctx, appID, filter)
+}
+
+func (c *Client) filterItems(ctx context.Context, appID string, filter *ItemFilter) (*FilterItemsResult, error) {
+	result := &FilterItemsResult{}
+	err := c.post(ctx, fmt.Sprintf("/item/app/%s/filter/", appID), filter, result)
+	return result, err
+}
+
+// validateFilter validates filter, against the app if it refers to fields.
+func (c *Client) validateFilter(ctx context.Context, appID string, filter *ItemFilter) error {
+	var app *Application
+	if filter.refersToFields() {
+		var err error
+		app, err = c.GetApplicationContext(ctx, appID)
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to validate item filter: %w", err)
+		}
+	}
+
+	return filter.Validate(app)
+}
+
+// IterateItems returns an iterator over every item matching the filter. The
+// filter's limit sets the page size, 500 by default. The filter is validated
+// once, as by FilterItems, before the first page is fetched.
+func (c *Client) IterateItems(appID string, filter *ItemFilter) *Iterator[Item] {
+	return c.IterateItemsContext(context.Background(), appID, filter)
+}
+
+func (c *Client) IterateItemsContext(ctx context.Context, appID string, filter *ItemFilter) *Iterator[Item] {
+	if filter == nil {
+		filter = NewItemFilter()
+	}
+
+	limit := filter.limit
+	if limit == 0 {
+		limit = maxFilterLimit
+	}
+
+	start := filter.offset
+	return NewIterator(ctx, limit, func(ctx context.Context, limit, offset int) ([]Item, int, error) {
+		if offset == 0 {
+			if err := c.validateFilter(ctx, appID, filter); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		page := *filter
+		page.limit = limit
+		page.offset = start + offset
+
+		result, err := c.filterItems(ctx, appID, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return result.Items, result.Filtered - start, nil
+	})
+}
@@ -0,0 +1,97 @@
+package podio_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func TestItemFilterLimit(t *testing.T) {
+	for _, limit := range []int{0, 1, 500} {
+		if err := podio.NewItemFilter().Limit(limit).Validate(nil); err != nil {
+			t.Errorf("limit %d: %v", limit, err)
+		}
+	}
+
+	for _, limit := range []int{-1, 501} {
+		err := podio.NewItemFilter().Limit(limit).Validate(nil)
+		if err == nil || !strings.Contains(err.Error(), "between 0 and 500") {
+			t.Errorf("limit %d: err = %v, want the limit range", limit, err)
+		}
+	}
+}
+
+func filterApp() *podio.Application {
+	return &podio.Application{AppID: 1, Fields: []podio.Field{
+		{FieldID: 10, ExternalID: "status", Type: podio.FieldTypeCategory},
+		{FieldID: 11, ExternalID: "due", Type: podio.FieldTypeDate},
+		{FieldID: 12, ExternalID: "amount", Type: podio.FieldTypeMoney},
+	}}
+}
+
+func TestItemFilterValidate(t *testing.T) {
+	due := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter *podio.ItemFilter
+		want   string
+	}{
+		{"valid", podio.NewItemFilter().Category("status", 1).DateRange("due", due, time.Time{}).NumberFrom("amount", 10).SortBy("due", true), ""},
+		{"field by id", podio.NewItemFilter().Category("10", 1), ""},
+		{"built in keys", podio.NewItemFilter().CreatedBy(5).SortBy("last_edit_on", false), ""},
+		{"missing field", podio.NewItemFilter().Category("stage", 1), "app 1 has no field stage"},
+		{"type mismatch", podio.NewItemFilter().DateRange("amount", due, due), "field amount is a money field"},
+		{"missing sort field", podio.NewItemFilter().SortBy("priority", false), "no field priority to sort by"},
+		{"unsupported sort key", podio.NewItemFilter().SortBy("created_by", false), "can't be sorted by created_by"},
+		{"empty category", podio.NewItemFilter().Category("status"), "needs at least one option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate(filterApp())
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterItemsValidatesAgainstApp(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(*filterApp())
+	client := srv.NewClient()
+	appID := strconv.Itoa(app.AppID)
+
+	if _, err := client.FilterItems(appID, podio.NewItemFilter().Category("stage", 1)); err == nil || !strings.Contains(err.Error(), "has no field stage") {
+		t.Errorf("FilterItems err = %v, want the missing field", err)
+	}
+
+	it := client.IterateItems(appID, podio.NewItemFilter().NumberFrom("due", 1))
+	defer it.Close()
+	if it.Next() {
+		t.Error("iterated items with an invalid filter")
+	}
+	if err := it.Err(); err == nil || !strings.Contains(err.Error(), "field due is a date field") {
+		t.Errorf("IterateItems err = %v, want the type mismatch", err)
+	}
+
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && strings.HasPrefix(r.Path, "/item/") {
+			t.Errorf("an invalid filter was sent: %s %s", r.Method, r.Path)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package podio
+
+import (
+	"context"
+	"fmt"
+)
+
+// HookEvent is the type of event a hook is called for.
+type HookEvent string
+
+const (
+	// Events of app hooks.
+	HookEventItemCreate    HookEvent = "item.create"
+	HookEventItemUpdate    HookEvent = "item.update"
+	HookEventItemDelete    HookEvent = "item.delete"
+	HookEventCommentCreate HookEvent = "comment.create"
+	HookEventCommentDelete HookEvent = "comment.delete"
+	HookEventFileChange    HookEvent = "file.change"
+	HookEventAppUpdate     HookEvent = "app.update"
+	HookEventAppDelete     HookEvent = "app.delete"
+	HookEventFormCreate    HookEvent = "form.create"
+	HookEventFormUpdate    HookEvent = "form.update"
+	HookEventFormDelete    HookEvent = "form.delete"
+	HookEventFieldCreate   HookEvent = "field.create"
+	HookEventFieldUpdate   HookEvent = "field.update"
+	HookEventFieldDelete   HookEvent = "field.delete"
+	HookEventTagAdd        HookEvent = "tag.add"
+	HookEventTagDelete     HookEvent = "tag.delete"
+
+	// Events of space hooks.
+	HookEventAppCreate    HookEvent = "app.create"
+	HookEventTaskCreate   HookEvent = "task.create"
+	HookEventTaskUpdate   HookEvent = "task.update"
+	HookEventTaskDelete   HookEvent = "task.delete"
+	HookEventMemberAdd    HookEvent = "member.add"
+	HookEventMemberRemove HookEvent = "member.remove"
+	HookEventStatusCreate HookEvent = "status.create"
+	HookEventStatusUpdate HookEvent = "status.update"
+	HookEventStatusDelete HookEvent = "status.delete"
+
+	// HookEventVerify is sent to a hook's url when verification is requested.
+	HookEventVerify HookEvent = "hook.verify"
+)
+
+// HookRefType is the type of object a hook is placed on.
+type HookRefType string
+
+const (
+	HookRefApp      HookRefType = "app"
+	HookRefAppField HookRefType = "app_field"
+	HookRefSpace    HookRefType = "space"
+)
+
+// HookRef is the object a hook is placed on. Item events are received
+// through a hook on the item's app.
+type HookRef struct {
+	Type HookRefType
+	ID   int
+}
+
+// HookRef returns the reference to place hooks on the app.
+func (a *Application) HookRef() HookRef {
+	return HookRef{Type: HookRefApp, ID: a.AppID}
+}
+
+// HookRef returns the reference to place hooks on the field.
+func (f *Field) HookRef() HookRef {
+	return HookRef{Type: HookRefAppField, ID: f.FieldID}
+}
+
+// HookRef returns the reference to place hooks on the space.
+func (s *Space) HookRef() HookRef {
+	return HookRef{Type: HookRefSpace, ID: s.ID}
+}
+
+func (r HookRef) path() string {
+	return fmt.Sprintf("/hook/%s/%d/", r.Type, r.ID)
+}
+
+type Hook struct {
+	// "hook_id": The id of the hook,
+	HookID int `json:"hook_id,omitempty"`
+	// "status": The status of the hook, either "inactive" or "active",
+	Status string `json:"status,omitempty"`
+	// "type": The type of events the hook is called for,
+	Type HookEvent `json:"type,omitempty"`
+	// "url": The url the hook posts events to,
+	URL string `json:"url,omitempty"`
+	// "created_on": The date and time the hook was created,
+	CreatedOn string `json:"created_on,omitempty"`
+	// "created_by": The entity who created the hook,
+	CreatedBy User `json:"created_by,omitempty"`
+}
+
+type CreateHookParams struct {
+	URL  string    `json:"url"`
+	Type HookEvent `json:"type"`
+}
+
+// CreateHook creates an inactive hook on ref. The hook is activated once
+// its url has been verified with RequestHookVerification and
+// ValidateHookVerification.
+func (c *Client) CreateHook(ref HookRef, params CreateHookParams) (*Hook, error) {
+	return c.CreateHookContext(context.Background(), ref, params)
+}
+
+func (c *Client) CreateHookContext(ctx context.Context, ref HookRef, params CreateHookParams) (*Hook, error) {
+	data := &struct {
+		HookID int `json:"hook_id"`
+	}{}
+	err := c.post(ctx, ref.path(), params, data)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to create hook: %w", err)
+	}
+
+	return &Hook{HookID: data.HookID, Status: "inactive", Type: params.Type, URL: params.URL}, nil
+}
+
+func (c *Client) GetHooks(ref HookRef) (*[]Hook, error) {
+	return c.GetHooksContext(context.Background(), ref)
+}
+
+func (c *Client) GetHooksContext(ctx context.Context, ref HookRef) (*[]Hook, error) {
+	return collect(c.ListHooksContext(ctx, ref))
+}
+
+// ListHooks returns an iterator over the hooks on ref.
+func (c *Client) ListHooks(ref HookRef) *Iterator[Hook] {
+	return c.ListHooksContext(context.Background(), ref)
+}
+
+func (c *Client) ListHooksContext(ctx context.Context, ref HookRef) *Iterator[Hook] {
+	return NewIterator(ctx, 0, unpaginated[Hook](c, ref.path()))
+}
+
+func (c *Client) DeleteHook(hookID string) error {
+	return c.DeleteHookContext(context.Background(), hookID)
+}
+
+func (c *Client) DeleteHookContext(ctx context.Context, hookID string) error {
+	return c.delete(ctx, fmt.Sprintf("/hook/%s", hookID))
+}
+
+// RequestHookVerification makes Podio post a hook.verify event with a
+// verification code to the hook's url.
+func (c *Client) RequestHookVerification(hookID string) error {
+	return c.RequestHookVerificationContext(context.Background(), hookID)
+}
+
+func (c *Client) RequestHookVerificationContext(ctx context.Context, hookID string) error {
+	err := c.post(ctx, fmt.Sprintf("/hook/%s/verify/request", hookID), nil, nil)
+	if err != nil {
+		return fmt.Errorf("podio-go: failed to request hook verification: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateHookVerification activates a hook with the code received in its
+// hook.verify event.
+func (c *Client) ValidateHookVerification(hookID string, code string) error {
+	return c.ValidateHookVerificationContext(context.Background(), hookID, code)
+}
+
+func (c *Client) ValidateHookVerificationContext(ctx context.Context, hookID string, code string) error {
+	params := struct {
+		Code string `json:"code"`
+	}{code}
+	err := c.post(ctx, fmt.Sprintf("/hook/%s/verify/validate", hookID), params, nil)
+	if err != nil {
+		return fmt.Errorf("podio-go: failed to validate hook verification: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package podio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type Item struct {
+	// "item_id": The id of the item,
+	ItemID int `json:"item_id,omitempty"`
+	// "app_item_id": The id of the item, unique within the app,
+	AppItemID int `json:"app_item_id,omitempty"`
+	// "external_id": The external id of the item. This can be used to store an id from an external system on the item,
+	ExternalID string `json:"external_id,omitempty"`
+	// "title": The title of the item. This is made of up one of the fields below, or by the item name and id,
+	Title string `json:"title,omitempty"`
+	// "link": The full link to the item,
+	Link string `json:"link,omitempty"`
+	// "app": The app the item belongs to,
+	App Application `json:"app,omitempty"`
+	// "fields": The values for each field,
+	Fields []ItemField `json:"fields,omitempty"`
+	// "current_revision": The latest revision of the item,
+	CurrentRevision ItemRevision `json:"current_revision,omitempty"`
+	// "created_on": The date and time the item was created,
+	CreatedOn string `json:"created_on,omitempty"`
+	// "created_by": The entity who created the item,
+	CreatedBy User `json:"created_by,omitempty"`
+	// "last_event_on": The date and time of the last event on the item,
+	LastEventOn string `json:"last_event_on,omitempty"`
+	// "rights": The array of rights the active user has on the item,
+	Rights []string `json:"rights,omitempty"`
+}
+
+type ItemField struct {
+	// "field_id": The id of the field,
+	FieldID int `json:"field_id,omitempty"`
+	// "external_id": The external id of the field,
+	ExternalID string `json:"external_id,omitempty"`
+	// "type": The type of the field (see area for more information),
+	Type string `json:"type,omitempty"`
+	// "label": The current label of the field,
+	Label string `json:"label,omitempty"`
+	// "values": The values of the field, in the format of the field type,
+	Values json.RawMessage `json:"values,omitempty"`
+}
+
+type ItemRevision struct {
+	// "revision": The revision number,
+	Revision int `json:"revision,omitempty"`
+	// "app_revision": The revision of the app at the time,
+	AppRevision int `json:"app_revision,omitempty"`
+	// "type": The type of revision, either "creation", "update" or "delete",
+	Type string `json:"type,omitempty"`
+	// "created_by": The entity who made the revision,
+	CreatedBy User `json:"created_by,omitempty"`
+	// "created_on": The date and time the revision was made,
+	CreatedOn string `json:"created_on,omitempty"`
+}
+
+// Field returns the value of the field with the given external id or field
+// id, or nil if the item has no value for it.
+func (i *Item) Field(key string) *ItemField {
+	for n := range i.Fields {
+		field := &i.Fields[n]
+		if field.ExternalID == key || strconv.Itoa(field.FieldID) == key {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// ItemFields holds the values to set on an item, keyed by field id or
+// external id.
+type ItemFields map[string]interface{}
+
+// Set sets the values of field, keyed by its external id if it has one.
+func (f ItemFields) Set(field Field, values interface{}) {
+	if field.ExternalID != "" {
+		f.SetByExternalID(field.ExternalID, values)
+		return
+	}
+
+	f.SetByID(field.FieldID, values)
+}
+
+// SetByID sets the values of the field with the given id.
+func (f ItemFields) SetByID(fieldID int, values interface{}) {
+	f[strconv.Itoa(fieldID)] = values
+}
+
+// SetByExternalID sets the values of the field with the given external id.
+func (f ItemFields) SetByExternalID(externalID string, values interface{}) {
+	f[externalID] = values
+}
+
+type CreateItemParams struct {
+	ExternalID string     `json:"external_id,omitempty"`
+	Fields     ItemFields `json:"fields,omitempty"`
+}
+
+func (c *Client) GetItem(itemID string) (*Item, error) {
+	return c.GetItemContext(context.Background(), itemID)
+}
+
+func (c *Client) GetItemContext(ctx context.Context, itemID string) (*Item, error) {
+	item := &Item{}
+	err := c.get(ctx, fmt.Sprintf("/item/%s", itemID), item)
+	return item, err
+}
+
+func (c *Client) GetItemByAppItemID(appID string, appItemID string) (*Item, error) {
+	return c.GetItemByAppItemIDContext(context.Background(), appID, appItemID)
+}
+
+func (c *Client) GetItemByAppItemIDContext(ctx context.Context, appID string, appItemID string) (*Item, error) {
+	item := &Item{}
+	err := c.get(ctx, fmt.Sprintf("/app/%s/item/%s", appID, appItemID), item)
+	return item, err
+}
+
+func (c *Client) GetItemByExternalID(appID string, externalID string) (*Item, error) {
+	return c.GetItemByExternalIDContext(context.Background(), appID, externalID)
+}
+
+func (c *Client) GetItemByExternalIDContext(ctx context.Context, appID string, externalID string) (*Item, error) {
+	item := &Item{}
+	err := c.get(ctx, fmt.Sprintf("/item/app/%s/external_id/%s", appID, url.PathEscape(externalID)), item)
+	return item, err
+}
+
+func (c *Client) CreateItem(appID string, params CreateItemParams) (*Item, error) {
+	return c.CreateItemContext(context.Background(), appID, params)
+}
+
+func (c *Client) CreateItemContext(ctx context.Context, appID string, params CreateItemParams) (*Item, error) {
+	data := &struct {
+		ItemID int `json:"item_id"`
+	}{}
+	err := c.post(ctx, fmt.Sprintf("/item/app/%s/", appID), params, data)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to create item: %w", err)
+	}
+
+	return c.GetItemContext(ctx, strconv.Itoa(data.ItemID))
+}
+
+func (c *Client) UpdateItem(itemID string, params CreateItemParams) (*Item, error) {
+	return c.UpdateItemContext(context.Background(), itemID, params)
+}
+
+func (c *Client) UpdateItemContext(ctx context.Context, itemID string, params CreateItemParams) (*Item, error) {
+	err := c.put(ctx, fmt.Sprintf("/item/%s", itemID), params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to update item: %w", err)
+	}
+
+	return c.GetItemContext(ctx, itemID)
+}
+
+func (c *Client) DeleteItem(itemID string) error {
+	return c.DeleteItemContext(context.Background(), itemID)
+}
+
+func (c *Client) DeleteItemContext(ctx context.Context, itemID string) error {
+	return c.delete(ctx, fmt.Sprintf("/item/%s", itemID))
+}
+
+func (c *Client) GetItemRevisions(itemID string) (*[]ItemRevision, error) {
+	return c.GetItemRevisionsContext(context.Background(), itemID)
+}
+
+func (c *Client) GetItemRevisionsContext(ctx context.Context, itemID string) (*[]ItemRevision, error) {
+	return collect(c.ListItemRevisionsContext(ctx, itemID))
+}
+
+// ListItemRevisions returns an iterator over the revisions of an item.
+func (c *Client) ListItemRevisions(itemID string) *Iterator[ItemRevision] {
+	return c.ListItemRevisionsContext(context.Background(), itemID)
+}
+
+func (c *Client) ListItemRevisionsContext(ctx context.Context, itemID string) *Iterator[ItemRevision] {
+	return NewIterator(ctx, 0, unpaginated[ItemRevision](c, fmt.Sprintf("/item/%s/revision/", itemID)))
+}
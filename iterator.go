@@ -0,0 +1,167 @@
+package podio
+
+import (
+	"context"
+)
+
+// PageFunc fetches up to limit results, skipping offset. It returns the total
+// number of results, or -1 if the endpoint doesn't report it.
+type PageFunc[T any] func(ctx context.Context, limit, offset int) (results []T, total int, err error)
+
+// Iterator pages through the results of a list call. The next page is
+// fetched in the background while the current one is consumed.
+//
+//	it := client.ListApplications("1234")
+//	defer it.Close()
+//	for it.Next() {
+//		app := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  PageFunc[T]
+	limit  int
+	offset int
+
+	page     []T
+	current  T
+	total    int
+	finished bool
+	err      error
+	next     chan pageResult[T]
+}
+
+type pageResult[T any] struct {
+	results []T
+	total   int
+	err     error
+}
+
+// NewIterator returns an iterator fetching pages of limit results with fetch.
+func NewIterator[T any](ctx context.Context, limit int, fetch PageFunc[T]) *Iterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Iterator[T]{
+		ctx:    ctx,
+		cancel: cancel,
+		fetch:  fetch,
+		limit:  limit,
+		total:  -1,
+	}
+}
+
+// Next advances to the next result, waiting for the next page if needed. It
+// returns false when there are no more results, an error occurred or the
+// iterator was closed.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.finished {
+			it.Close()
+			return false
+		}
+
+		if it.next == nil {
+			it.prefetch()
+		}
+
+		result := <-it.next
+		it.next = nil
+		if result.err != nil {
+			it.err = result.err
+			it.Close()
+			return false
+		}
+
+		it.page = result.results
+		it.total = result.total
+		it.offset += len(result.results)
+		it.finished = len(result.results) == 0 || len(result.results) < it.limit || (result.total >= 0 && it.offset >= result.total)
+
+		if !it.finished {
+			it.prefetch()
+		}
+
+		if len(it.page) == 0 {
+			it.Close()
+			return false
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// prefetch starts fetching the page at the current offset.
+func (it *Iterator[T]) prefetch() {
+	next := make(chan pageResult[T], 1)
+	it.next = next
+
+	ctx, limit, offset := it.ctx, it.limit, it.offset
+	go func() {
+		results, total, err := it.fetch(ctx, limit, offset)
+		next <- pageResult[T]{results, total, err}
+	}()
+}
+
+// Value returns the current result.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Total returns the total number of results, or -1 if unknown or before the
+// first page was fetched.
+func (it *Iterator[T]) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration, cancelling any page being fetched. It is safe
+// to call more than once.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+	it.page = nil
+	it.finished = true
+}
+
+// All collects the remaining results.
+func (it *Iterator[T]) All() ([]T, error) {
+	defer it.Close()
+
+	var all []T
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
+
+// unpaginated returns a PageFunc for list endpoints that return every result
+// at once.
+func unpaginated[T any](c *Client, path string) PageFunc[T] {
+	return func(ctx context.Context, limit, offset int) ([]T, int, error) {
+		var results []T
+		if err := c.get(ctx, path, &results); err != nil {
+			return nil, 0, err
+		}
+
+		return results, len(results), nil
+	}
+}
+
+// collect drains an iterator into the *[]T the Get list calls return.
+func collect[T any](it *Iterator[T]) (*[]T, error) {
+	all, err := it.All()
+	if all == nil {
+		all = []T{}
+	}
+
+	return &all, err
+}
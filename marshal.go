@@ -0,0 +1,559 @@
+package podio
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrFieldNotFound is returned by Marshal and Unmarshal when a struct
+	// field is tagged with an external id the app has no field for.
+	ErrFieldNotFound = fmt.Errorf("podio-go: no such field")
+
+	// ErrFieldTypeMismatch is returned by Marshal and Unmarshal when the type
+	// of a struct field can't hold values of the app field it is tagged with.
+	ErrFieldTypeMismatch = fmt.Errorf("podio-go: field type mismatch")
+)
+
+// FieldError describes why a struct field could not be mapped to an app field.
+type FieldError struct {
+	// StructField is the name of the Go struct field.
+	StructField string
+	// Key is the external id from the podio tag.
+	Key string
+	// FieldType is the type of the app field, empty if there is none.
+	FieldType string
+	// Err is ErrFieldNotFound, ErrFieldTypeMismatch or a decoding error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.FieldType == "" {
+		return fmt.Sprintf("podio-go: struct field %s: %s: %v", e.StructField, e.Key, e.Err)
+	}
+
+	return fmt.Sprintf("podio-go: struct field %s: %s (%s field): %v", e.StructField, e.Key, e.FieldType, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	fieldValueType = reflect.TypeOf((*FieldValue)(nil)).Elem()
+	rawValueType   = reflect.TypeOf(RawValue{})
+)
+
+// taggedField is a struct field with a podio tag.
+type taggedField struct {
+	name      string
+	key       string
+	omitEmpty bool
+	index     []int
+}
+
+// taggedFields returns the fields of a struct type tagged with podio:"external-id".
+// Tags may have an omitempty option, and "-" skips a field. Untagged fields
+// are skipped as well, since Podio external ids rarely match Go field names.
+func taggedFields(t reflect.Type) []taggedField {
+	var fields []taggedField
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("podio")
+
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+			for _, embedded := range taggedFields(sf.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+
+		if !tagged || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := taggedField{name: sf.Name, key: parts[0], index: []int{i}}
+		for _, option := range parts[1:] {
+			if option == "omitempty" {
+				field.omitEmpty = true
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+func structValue(v interface{}, needPointer bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	} else if needPointer {
+		return reflect.Value{}, fmt.Errorf("podio-go: expected a non-nil pointer to a struct, got %T", v)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("podio-go: expected a struct, got %T", v)
+	}
+
+	return rv, nil
+}
+
+// Marshal converts v, a struct or pointer to a struct with podio tags, into
+// item fields for CreateItem and UpdateItem. Tags refer to fields of app by
+// external id:
+//
+//	type Customer struct {
+//		Name   string    `podio:"customer-name"`
+//		Status string    `podio:"status"` // category option text, or int for the option id
+//		Since  time.Time `podio:"customer-since,omitempty"`
+//	}
+//
+// Struct fields may also hold FieldValue types such as MoneyValue, and
+// slices hold multiple values. Nil pointers, and fields tagged with read only
+// calculation fields, are skipped.
+func Marshal(app *Application, v interface{}) (ItemFields, error) {
+	if app == nil {
+		return nil, fmt.Errorf("podio-go: Marshal needs the app to map fields with")
+	}
+
+	rv, err := structValue(v, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := ItemFields{}
+	for _, tf := range taggedFields(rv.Type()) {
+		field := app.Field(tf.key)
+		if field == nil {
+			return nil, &FieldError{StructField: tf.name, Key: tf.key, Err: ErrFieldNotFound}
+		}
+
+		if field.Type == FieldTypeCalculation {
+			continue
+		}
+
+		if err := checkFieldType(field, rv.Type().FieldByIndex(tf.index).Type, true); err != nil {
+			return nil, &FieldError{StructField: tf.name, Key: tf.key, FieldType: field.Type, Err: err}
+		}
+
+		fv := rv.FieldByIndex(tf.index)
+		if (fv.Kind() == reflect.Ptr && fv.IsNil()) || (tf.omitEmpty && fv.IsZero()) {
+			continue
+		}
+
+		values, err := toFieldValues(field, fv)
+		if err != nil {
+			return nil, &FieldError{StructField: tf.name, Key: tf.key, FieldType: field.Type, Err: err}
+		}
+
+		fields.SetByExternalID(tf.key, values)
+	}
+
+	return fields, nil
+}
+
+// Unmarshal fills v, a pointer to a struct with podio tags, from the values
+// of item. See Marshal for the tags. Fields the item has no values for are
+// set to their zero value, but their types are still checked against the
+// app fields.
+func Unmarshal(app *Application, item *Item, v interface{}) error {
+	if app == nil {
+		return fmt.Errorf("podio-go: Unmarshal needs the app to map fields with")
+	}
+	if item == nil {
+		return fmt.Errorf("podio-go: Unmarshal needs an item to read values from")
+	}
+
+	rv, err := structValue(v, true)
+	if err != nil {
+		return err
+	}
+
+	for _, tf := range taggedFields(rv.Type()) {
+		field := app.Field(tf.key)
+		if field == nil {
+			return &FieldError{StructField: tf.name, Key: tf.key, Err: ErrFieldNotFound}
+		}
+
+		if err := checkFieldType(field, rv.Type().FieldByIndex(tf.index).Type, false); err != nil {
+			return &FieldError{StructField: tf.name, Key: tf.key, FieldType: field.Type, Err: err}
+		}
+
+		fv := rv.FieldByIndex(tf.index)
+		fv.Set(reflect.Zero(fv.Type()))
+
+		itemField := item.Field(tf.key)
+		if itemField == nil {
+			itemField = item.Field(strconv.Itoa(field.FieldID))
+		}
+		if itemField == nil {
+			continue
+		}
+
+		values, err := DecodeFieldValues(field.Type, itemField.Values)
+		if err != nil {
+			return &FieldError{StructField: tf.name, Key: tf.key, FieldType: field.Type, Err: err}
+		}
+
+		if err := fromFieldValues(values, fv); err != nil {
+			return &FieldError{StructField: tf.name, Key: tf.key, FieldType: field.Type, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// checkFieldType checks that struct fields of type t can hold values of
+// field, so a mismatch is reported whether or not there is a value to
+// convert. Interface types are only checked when a value is converted.
+func checkFieldType(field *Field, t reflect.Type, marshal bool) error {
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		t = t.Elem()
+	}
+	for t.Kind() == reflect.Ptr && !t.Implements(fieldValueType) {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Interface || t == rawValueType || t == reflect.PtrTo(rawValueType) {
+		return nil
+	}
+
+	if t.Implements(fieldValueType) || reflect.PtrTo(t).Implements(fieldValueType) {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		fieldType := reflect.New(t).Interface().(FieldValue).FieldType()
+		if fieldType != field.Type {
+			return fmt.Errorf("%w: %s is a %s value", ErrFieldTypeMismatch, t, fieldType)
+		}
+		return nil
+	}
+
+	isString := t.Kind() == reflect.String
+	isNumber := isNumberType(t)
+	isInt := isNumber && t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64
+
+	ok := true
+	switch field.Type {
+	case FieldTypeText, FieldTypeLocation, FieldTypeEmail, FieldTypePhone, FieldTypeLink, FieldTypeTel:
+		ok = isString
+	case FieldTypeNumber, FieldTypeMoney, FieldTypeProgress:
+		ok = isNumber
+	case FieldTypeDuration:
+		ok = isNumber || t == durationType
+	case FieldTypeDate:
+		ok = t == timeType
+	case FieldTypeCategory:
+		ok = isString || isInt || (!marshal && isNumber)
+	case FieldTypeApp, FieldTypeContact, FieldTypeImage:
+		ok = isInt || (!marshal && (isNumber || isString))
+	case FieldTypeCalculation:
+		ok = isString || isNumber || t == timeType
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %s can't hold %s values", ErrFieldTypeMismatch, t, field.Type)
+	}
+
+	return nil
+}
+
+func isNumberType(t reflect.Type) bool {
+	if t == durationType {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func toFieldValues(field *Field, fv reflect.Value) ([]FieldValue, error) {
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		values := make([]FieldValue, 0, fv.Len())
+		for i := 0; i < fv.Len(); i++ {
+			value, err := toFieldValue(field, fv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	value, err := toFieldValue(field, fv)
+	if err != nil {
+		return nil, err
+	}
+
+	return []FieldValue{value}, nil
+}
+
+func toFieldValue(field *Field, fv reflect.Value) (FieldValue, error) {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil, fmt.Errorf("%w: nil value", ErrFieldTypeMismatch)
+		}
+		if fv.Type().Implements(fieldValueType) {
+			break
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.Type().Implements(fieldValueType) {
+		value := fv.Interface().(FieldValue)
+		if value.FieldType() != field.Type {
+			return nil, fmt.Errorf("%w: %s is a %s value", ErrFieldTypeMismatch, fv.Type(), value.FieldType())
+		}
+		return value, nil
+	}
+
+	mismatch := fmt.Errorf("%w: can't set from %s", ErrFieldTypeMismatch, fv.Type())
+
+	switch field.Type {
+	case FieldTypeText:
+		if fv.Kind() == reflect.String {
+			return TextValue{Value: fv.String()}, nil
+		}
+	case FieldTypeNumber:
+		if n, ok := numberOf(fv); ok {
+			return NumberValue{Value: n}, nil
+		}
+	case FieldTypeMoney:
+		if n, ok := numberOf(fv); ok {
+			return MoneyValue{Value: n, Currency: field.defaultCurrency()}, nil
+		}
+	case FieldTypeProgress:
+		if n, ok := numberOf(fv); ok {
+			return ProgressValue{Value: int(n)}, nil
+		}
+	case FieldTypeDuration:
+		if fv.Type() == durationType {
+			return DurationValue{Value: time.Duration(fv.Int())}, nil
+		}
+		if n, ok := numberOf(fv); ok {
+			return DurationValue{Value: time.Duration(n) * time.Second}, nil
+		}
+	case FieldTypeDate:
+		if fv.Type() == timeType {
+			t := fv.Interface().(time.Time)
+			hasTime := t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0
+			return DateValue{Start: t, HasTime: hasTime}, nil
+		}
+	case FieldTypeCategory:
+		if id, ok := intOf(fv); ok {
+			return CategoryValue{ID: id}, nil
+		}
+		if fv.Kind() == reflect.String {
+			for _, option := range field.CategoryOptions() {
+				if option.Text == fv.String() && option.Status != "deleted" {
+					return CategoryValue{ID: option.ID, Text: option.Text}, nil
+				}
+			}
+			return nil, fmt.Errorf("%w: no category option %q", ErrFieldTypeMismatch, fv.String())
+		}
+	case FieldTypeApp:
+		if id, ok := intOf(fv); ok {
+			return AppReferenceValue{ItemID: id}, nil
+		}
+	case FieldTypeContact:
+		if id, ok := intOf(fv); ok {
+			return ContactValue{ProfileID: id}, nil
+		}
+	case FieldTypeImage:
+		if id, ok := intOf(fv); ok {
+			return ImageValue{FileID: id}, nil
+		}
+	case FieldTypeLocation:
+		if fv.Kind() == reflect.String {
+			return LocationValue{Value: fv.String()}, nil
+		}
+	case FieldTypeEmail:
+		if fv.Kind() == reflect.String {
+			return EmailValue{Type: "other", Value: fv.String()}, nil
+		}
+	case FieldTypePhone:
+		if fv.Kind() == reflect.String {
+			return PhoneValue{Type: "other", Value: fv.String()}, nil
+		}
+	case FieldTypeLink:
+		if fv.Kind() == reflect.String {
+			return LinkValue{URL: fv.String()}, nil
+		}
+	case FieldTypeTel:
+		if fv.Kind() == reflect.String {
+			return TelValue{Number: fv.String()}, nil
+		}
+	}
+
+	return nil, mismatch
+}
+
+func fromFieldValues(values []FieldValue, fv reflect.Value) error {
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := fromFieldValue(value, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return fromFieldValue(values[0], fv)
+}
+
+// fromFieldValue sets fv from value, a pointer as returned by DecodeFieldValues.
+func fromFieldValue(value FieldValue, fv reflect.Value) error {
+	rvalue := reflect.ValueOf(value)
+
+	switch {
+	case rvalue.Type().AssignableTo(fv.Type()):
+		fv.Set(rvalue)
+		return nil
+	case rvalue.Elem().Type().AssignableTo(fv.Type()):
+		fv.Set(rvalue.Elem())
+		return nil
+	case fv.Kind() == reflect.Ptr:
+		elem := reflect.New(fv.Type().Elem())
+		if err := fromFieldValue(value, elem.Elem()); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+
+	ok := false
+	switch v := value.(type) {
+	case *TextValue:
+		ok = setString(fv, v.Value)
+	case *NumberValue:
+		ok = setNumber(fv, v.Value)
+	case *MoneyValue:
+		ok = setNumber(fv, v.Value)
+	case *ProgressValue:
+		ok = setNumber(fv, float64(v.Value))
+	case *DurationValue:
+		if fv.Type() == durationType {
+			fv.SetInt(int64(v.Value))
+			ok = true
+		} else {
+			ok = setNumber(fv, v.Value.Seconds())
+		}
+	case *DateValue:
+		if fv.Type() == timeType {
+			fv.Set(reflect.ValueOf(v.Start))
+			ok = true
+		}
+	case *CategoryValue:
+		ok = setString(fv, v.Text) || setNumber(fv, float64(v.ID))
+	case *AppReferenceValue:
+		ok = setNumber(fv, float64(v.ItemID)) || setString(fv, v.Title)
+	case *ContactValue:
+		ok = setNumber(fv, float64(v.ProfileID)) || setString(fv, v.Name)
+	case *ImageValue:
+		ok = setNumber(fv, float64(v.FileID)) || setString(fv, v.Link)
+	case *LocationValue:
+		ok = setString(fv, v.Value)
+	case *EmailValue:
+		ok = setString(fv, v.Value)
+	case *PhoneValue:
+		ok = setString(fv, v.Value)
+	case *LinkValue:
+		ok = setString(fv, v.URL)
+	case *TelValue:
+		ok = setString(fv, v.Number)
+	case *CalculationValue:
+		ok = setString(fv, v.Value)
+		if n, err := strconv.ParseFloat(v.Value, 64); !ok && err == nil {
+			ok = setNumber(fv, n)
+		}
+		if t, err := time.Parse(podioDateTime, v.Value); !ok && err == nil && fv.Type() == timeType {
+			fv.Set(reflect.ValueOf(t))
+			ok = true
+		}
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: can't set %s from %s value", ErrFieldTypeMismatch, fv.Type(), value.FieldType())
+	}
+
+	return nil
+}
+
+func setString(fv reflect.Value, s string) bool {
+	if fv.Kind() != reflect.String {
+		return false
+	}
+
+	fv.SetString(s)
+	return true
+}
+
+// setNumber sets numeric kinds other than time.Duration.
+func setNumber(fv reflect.Value, n float64) bool {
+	if fv.Type() == durationType {
+		return false
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		fv.SetFloat(n)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func numberOf(fv reflect.Value) (float64, bool) {
+	if fv.Type() == durationType {
+		return 0, false
+	}
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	}
+
+	return 0, false
+}
+
+func intOf(fv reflect.Value) (int, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(fv.Int()), fv.Type() != durationType
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(fv.Uint()), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,108 @@
+package podio_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+func marshalApp() *podio.Application {
+	return &podio.Application{Fields: []podio.Field{
+		{FieldID: 1, ExternalID: "name", Type: podio.FieldTypeText},
+		{FieldID: 2, ExternalID: "since", Type: podio.FieldTypeDate},
+	}}
+}
+
+func TestMarshalChecksTypesWithoutValues(t *testing.T) {
+	var v struct {
+		Name  string `podio:"name"`
+		Since int    `podio:"since,omitempty"`
+	}
+
+	_, err := podio.Marshal(marshalApp(), v)
+	if !errors.Is(err, podio.ErrFieldTypeMismatch) {
+		t.Fatalf("err = %v, want ErrFieldTypeMismatch for the empty date field", err)
+	}
+}
+
+func TestUnmarshalChecksTypesWithoutValues(t *testing.T) {
+	var v struct {
+		Name  string `podio:"name"`
+		Since string `podio:"since"`
+	}
+
+	err := podio.Unmarshal(marshalApp(), &podio.Item{}, &v)
+
+	var fieldErr *podio.FieldError
+	if !errors.As(err, &fieldErr) || !errors.Is(err, podio.ErrFieldTypeMismatch) || fieldErr.Key != "since" {
+		t.Fatalf("err = %v, want ErrFieldTypeMismatch for since", err)
+	}
+}
+
+func TestMarshalAcceptsMatchingTypes(t *testing.T) {
+	v := struct {
+		Name  *string          `podio:"name"`
+		Since []time.Time      `podio:"since"`
+		Value *podio.TextValue `podio:"name,omitempty"`
+	}{Since: []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+
+	if _, err := podio.Marshal(marshalApp(), v); err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := podio.Unmarshal(marshalApp(), &podio.Item{}, &v); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+}
+
+func TestMarshalNilApp(t *testing.T) {
+	v := struct {
+		Name string `podio:"name"`
+	}{Name: "Ada"}
+
+	if _, err := podio.Marshal(nil, v); err == nil {
+		t.Error("Marshal with a nil app returned no error")
+	}
+	if err := podio.Unmarshal(nil, &podio.Item{}, &v); err == nil {
+		t.Error("Unmarshal with a nil app returned no error")
+	}
+	if err := podio.Unmarshal(marshalApp(), nil, &v); err == nil {
+		t.Error("Unmarshal with a nil item returned no error")
+	}
+}
+
+func TestMarshalFieldNotFound(t *testing.T) {
+	v := struct {
+		Name  string `podio:"name"`
+		Email string `podio:"email"`
+	}{Name: "Ada", Email: "ada@example.com"}
+
+	_, err := podio.Marshal(marshalApp(), v)
+
+	var fieldErr *podio.FieldError
+	if !errors.Is(err, podio.ErrFieldNotFound) || !errors.As(err, &fieldErr) || fieldErr.StructField != "Email" {
+		t.Errorf("Marshal err = %v, want ErrFieldNotFound for Email", err)
+	}
+
+	err = podio.Unmarshal(marshalApp(), &podio.Item{}, &v)
+	if !errors.Is(err, podio.ErrFieldNotFound) || errors.Is(err, podio.ErrFieldTypeMismatch) {
+		t.Errorf("Unmarshal err = %v, want ErrFieldNotFound", err)
+	}
+}
+
+func TestMarshalFieldTypeMismatch(t *testing.T) {
+	v := struct {
+		Name float64 `podio:"name"`
+	}{Name: 1}
+
+	_, err := podio.Marshal(marshalApp(), v)
+	if !errors.Is(err, podio.ErrFieldTypeMismatch) || errors.Is(err, podio.ErrFieldNotFound) {
+		t.Errorf("Marshal err = %v, want ErrFieldTypeMismatch", err)
+	}
+
+	err = podio.Unmarshal(marshalApp(), &podio.Item{}, &v)
+	if !errors.Is(err, podio.ErrFieldTypeMismatch) {
+		t.Errorf("Unmarshal err = %v, want ErrFieldTypeMismatch", err)
+	}
+}
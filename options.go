@@ -0,0 +1,180 @@
+package podio
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a client created with NewClientWithOptions.
+type Option func(*ClientOptions)
+
+// Logger receives diagnostic messages from the client. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, v ...interface{}) {}
+
+// NewClientWithOptions creates a new Podio API client. Unlike NewClient, it
+// returns a *ConfigError listing every problem with the options instead of
+// panicking.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	options := ClientOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return newClient(options)
+}
+
+// WithCredentials sets the API key and secret of the client. They are required.
+func WithCredentials(apiKey, apiSecret string) Option {
+	return func(o *ClientOptions) {
+		o.ApiKey = apiKey
+		o.ApiSecret = apiSecret
+	}
+}
+
+// WithAPIURL sets the URL of the Podio API, https://api.podio.com by default.
+func WithAPIURL(apiURL string) Option {
+	return func(o *ClientOptions) {
+		o.ApiURL = apiURL
+	}
+}
+
+// WithAuthURL sets where users are sent to authorize the client, https://podio.com by default.
+func WithAuthURL(authURL string) Option {
+	return func(o *ClientOptions) {
+		o.AuthURL = authURL
+	}
+}
+
+// WithUserAgent sets the user agent sent with every call.
+func WithUserAgent(userAgent string) Option {
+	return func(o *ClientOptions) {
+		o.UserAgent = userAgent
+	}
+}
+
+// WithTimeout sets the overall timeout of a call, see ClientOptions.Timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithTransport sets the base transport calls are sent with.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *ClientOptions) {
+		o.Transport = transport
+	}
+}
+
+// WithHTTPClient sets the http.Client the client's settings are copied from.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *ClientOptions) {
+		o.HTTPClient = httpClient
+	}
+}
+
+// WithLogger sets the logger told about retries, token refreshes and throttling.
+func WithLogger(logger Logger) Option {
+	return func(o *ClientOptions) {
+		o.Logger = logger
+	}
+}
+
+// WithRetryPolicy sets how failed calls are retried.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *ClientOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithRateLimitThrottle sets how calls are slowed down as the rate limit quota runs low.
+func WithRateLimitThrottle(throttle *RateLimitThrottle) Option {
+	return func(o *ClientOptions) {
+		o.RateLimitThrottle = throttle
+	}
+}
+
+// WithTokenStore sets where tokens are persisted.
+func WithTokenStore(store TokenStore) Option {
+	return func(o *ClientOptions) {
+		o.TokenStore = store
+	}
+}
+
+// ConfigError is returned when a client is created with invalid options.
+type ConfigError struct {
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return "podio-go: invalid client options: " + strings.Join(e.Problems, "; ")
+}
+
+// validate checks every option, and returns the parsed API and auth URLs.
+func (o *ClientOptions) validate() (*url.URL, *url.URL, error) {
+	var problems []string
+
+	if o.ApiKey == "" {
+		problems = append(problems, "ApiKey is required")
+	}
+
+	if o.ApiSecret == "" {
+		problems = append(problems, "ApiSecret is required")
+	}
+
+	apiURL, err := parseBaseURL(o.ApiURL)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to parse API URL: %s", err))
+	}
+
+	authURL, err := parseBaseURL(o.AuthURL)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to parse auth URL: %s", err))
+	}
+
+	if p := o.RetryPolicy; p != nil {
+		if p.MaxAttempts < 1 {
+			problems = append(problems, "RetryPolicy.MaxAttempts must be at least 1")
+		}
+		if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+			problems = append(problems, "RetryPolicy backoff must not be negative")
+		}
+	}
+
+	if t := o.RateLimitThrottle; t != nil {
+		if t.Threshold < 0 {
+			problems = append(problems, "RateLimitThrottle.Threshold must not be negative")
+		}
+		if t.MaxDelay < 0 {
+			problems = append(problems, "RateLimitThrottle.MaxDelay must not be negative")
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, nil, &ConfigError{Problems: problems}
+	}
+
+	return apiURL, authURL, nil
+}
+
+func parseBaseURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("%q must be an absolute URL", raw)
+	}
+
+	return parsed, nil
+}
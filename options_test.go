@@ -0,0 +1,50 @@
+package podio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+func TestConfigErrorCombinesProblems(t *testing.T) {
+	_, err := podio.NewClientWithOptions(
+		podio.WithAPIURL("api.podio.com"),
+		podio.WithRetryPolicy(&podio.RetryPolicy{MaxAttempts: 0, InitialBackoff: -time.Second}),
+		podio.WithRateLimitThrottle(&podio.RateLimitThrottle{Threshold: -1, MaxDelay: -time.Second}),
+	)
+
+	var configErr *podio.ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("err = %v, want a ConfigError", err)
+	}
+
+	want := []string{
+		"ApiKey is required",
+		"ApiSecret is required",
+		"failed to parse API URL",
+		"RetryPolicy.MaxAttempts must be at least 1",
+		"RetryPolicy backoff must not be negative",
+		"RateLimitThrottle.Threshold must not be negative",
+		"RateLimitThrottle.MaxDelay must not be negative",
+	}
+	if len(configErr.Problems) != len(want) {
+		t.Fatalf("problems = %q, want %d", configErr.Problems, len(want))
+	}
+	for n, problem := range want {
+		if !strings.HasPrefix(configErr.Problems[n], problem) {
+			t.Errorf("problem %d = %q, want %q", n, configErr.Problems[n], problem)
+		}
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error %q doesn't mention %q", err, problem)
+		}
+	}
+}
+
+func TestConfigErrorValidOptions(t *testing.T) {
+	if _, err := podio.NewClientWithOptions(podio.WithCredentials("id", "secret")); err != nil {
+		t.Errorf("NewClientWithOptions: %v", err)
+	}
+}
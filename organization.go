@@ -1,6 +1,7 @@
 package podio
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -26,19 +27,38 @@ type Organization struct {
 }
 
 func (c *Client) GetOrganization(orgId string) (*Organization, error) {
+	return c.GetOrganizationContext(context.Background(), orgId)
+}
+
+func (c *Client) GetOrganizationContext(ctx context.Context, orgId string) (*Organization, error) {
 	org := &Organization{}
-	err := c.get(fmt.Sprintf("/org/%s", orgId), org)
+	err := c.get(ctx, fmt.Sprintf("/org/%s", orgId), org)
 	return org, err
 }
 
 func (c *Client) GetOrganizationBySlug(orgSlug string) (*Organization, error) {
+	return c.GetOrganizationBySlugContext(context.Background(), orgSlug)
+}
+
+func (c *Client) GetOrganizationBySlugContext(ctx context.Context, orgSlug string) (*Organization, error) {
 	org := &Organization{}
-	err := c.get(fmt.Sprintf("/org/url?url=https://podio.com/%s", orgSlug), org)
+	err := c.get(ctx, fmt.Sprintf("/org/url?url=https://podio.com/%s", orgSlug), org)
 	return org, err
 }
 
 func (c *Client) GetOrganizations() (*[]Organization, error) {
-	orgs := &[]Organization{}
-	err := c.get("/org/", orgs)
-	return orgs, err
+	return c.GetOrganizationsContext(context.Background())
+}
+
+func (c *Client) GetOrganizationsContext(ctx context.Context) (*[]Organization, error) {
+	return collect(c.ListOrganizationsContext(ctx))
+}
+
+// ListOrganizations returns an iterator over the organizations of the user.
+func (c *Client) ListOrganizations() *Iterator[Organization] {
+	return c.ListOrganizationsContext(context.Background())
+}
+
+func (c *Client) ListOrganizationsContext(ctx context.Context) *Iterator[Organization] {
+	return NewIterator(ctx, 0, unpaginated[Organization](c, "/org/"))
 }
@@ -3,11 +3,15 @@ package podio
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,6 +20,9 @@ type Client struct {
 	httpClient *http.Client
 	options    ClientOptions
 	apiURL     *url.URL
+	authURL    *url.URL
+	auth       *authenticatedTransport
+	limits     *rateLimiter
 }
 
 // ClientOptions are the options for a Podio API client.
@@ -24,39 +31,129 @@ type ClientOptions struct {
 	ApiSecret string
 	ApiURL    string
 	UserAgent string
+
+	// Transport is the base transport calls are sent with, e.g. to configure
+	// proxies, TLS or connection pooling. Authentication, rate limiting and
+	// retries are layered on top of it. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// HTTPClient, if set, is copied and used for its settings, such as the
+	// cookie jar and redirect policy. Its transport is used as the base
+	// transport unless Transport is set.
+	HTTPClient *http.Client
+
+	// Timeout is the overall timeout of a call, including retries. Defaults
+	// to the timeout of HTTPClient if set, or 30 seconds. A negative timeout
+	// disables it.
+	Timeout time.Duration
+
+	// RetryPolicy, if set, retries calls that failed with a transient error.
+	// See DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// RateLimitThrottle, if set, delays calls as the rate limit quota runs low.
+	RateLimitThrottle *RateLimitThrottle
+
+	// TokenStore, if set, persists tokens so they can be reused across runs
+	// and shared between processes. See Client.LoadToken.
+	TokenStore TokenStore
+
+	// AuthURL is where users are sent to authorize this client, see AuthCodeURL.
+	AuthURL string
+
+	// Logger, if set, is told about retries, token refreshes and throttling.
+	Logger Logger
 }
 
-// NewClient creates a new Podio API client with the given options.
+// NewClient creates a new Podio API client with the given options. It panics
+// if the options are invalid; see NewClientWithOptions for a constructor that
+// returns an error instead.
 func NewClient(options ClientOptions) *Client {
+	c, err := newClient(options)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func newClient(options ClientOptions) (*Client, error) {
 	if options.ApiURL == "" {
 		options.ApiURL = "https://api.podio.com"
 	}
 
-	if options.ApiKey == "" || options.ApiSecret == "" {
-		panic("podio-go: ApiKey and ApiSecret are required")
+	if options.AuthURL == "" {
+		options.AuthURL = "https://podio.com"
+	}
+
+	if options.Logger == nil {
+		options.Logger = nopLogger{}
 	}
 
-	apiURL, err := url.Parse(options.ApiURL)
+	apiURL, authURL, err := options.validate()
 	if err != nil {
-		panic(fmt.Errorf("podio-go: failed to parse API URL: %w", err))
+		return nil, err
 	}
 
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &authenticatedTransport{
-				options: options,
-				apiURL:  apiURL,
-			},
-		},
+	c := &Client{
 		options: options,
 		apiURL:  apiURL,
+		authURL: authURL,
+		limits:  &rateLimiter{throttle: options.RateLimitThrottle, logger: options.Logger},
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if options.HTTPClient != nil {
+		*httpClient = *options.HTTPClient
+	}
+
+	transport := options.Transport
+	if transport == nil {
+		transport = httpClient.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
 	}
+
+	transport = &rateLimitTransport{limits: c.limits, next: transport}
+
+	c.auth = &authenticatedTransport{
+		options: options,
+		grant:   c.doOAuthGrant,
+		next:    transport,
+	}
+	transport = c.auth
+
+	transport = &userAgentTransport{
+		userAgent: options.UserAgent,
+		apiURL:    apiURL,
+		next:      transport,
+	}
+
+	if options.RetryPolicy != nil {
+		transport = &retryTransport{policy: options.RetryPolicy, logger: options.Logger, next: transport}
+	}
+
+	httpClient.Transport = transport
+	if options.Timeout > 0 {
+		httpClient.Timeout = options.Timeout
+	} else if options.Timeout < 0 {
+		httpClient.Timeout = 0
+	}
+
+	c.httpClient = httpClient
+
+	return c, nil
 }
 
 // AuthenticateWithCreditentials authenticates with the given credentials.
 func (c *Client) AuthenticateWithCredentials(username, password string) error {
-	oauth, err := c.doOAuthGrant(oAuth2Request{
+	return c.AuthenticateWithCredentialsContext(context.Background(), username, password)
+}
+
+// AuthenticateWithCredentialsContext is AuthenticateWithCredentials with a context.
+func (c *Client) AuthenticateWithCredentialsContext(ctx context.Context, username, password string) error {
+	oauth, err := c.doOAuthGrant(ctx, oAuth2Request{
 		Username:     username,
 		Password:     password,
 		ClientID:     c.options.ApiKey,
@@ -68,51 +165,91 @@ func (c *Client) AuthenticateWithCredentials(username, password string) error {
 		return err
 	}
 
-	c.httpClient.Transport = &authenticatedTransport{
-		apiToken: oauth.AccessToken,
-		options:  c.options,
-		apiURL:   c.apiURL,
-	}
+	return c.auth.setToken(newToken(oauth))
+}
 
-	return nil
+// AuthenticateAsApp authenticates as an app using its app token (see Application.Token).
+func (c *Client) AuthenticateAsApp(appID, appToken string) error {
+	return c.AuthenticateAsAppContext(context.Background(), appID, appToken)
 }
 
-func (c *Client) get(path string, v interface{}) error {
-	resp, err := c.httpClient.Get(path)
+// AuthenticateAsAppContext is AuthenticateAsApp with a context.
+func (c *Client) AuthenticateAsAppContext(ctx context.Context, appID, appToken string) error {
+	oauth, err := c.doOAuthGrant(ctx, oAuth2Request{
+		AppID:        appID,
+		AppToken:     appToken,
+		ClientID:     c.options.ApiKey,
+		ClientSecret: c.options.ApiSecret,
+		GrantType:    "app",
+	})
+
 	if err != nil {
-		return fmt.Errorf("podio-go: failed to GET %s: %w", path, err)
+		return err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("podio-go: failed to GET %s: %s\nPayload: %s", path, resp.Status, string(output))
-	}
+	return c.auth.setToken(newToken(oauth))
+}
 
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err != nil {
-		return fmt.Errorf("podio-go: failed to decode response: %w", err)
-	}
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, v)
+}
 
-	return nil
+func (c *Client) post(ctx context.Context, path string, body, v interface{}) error {
+	return c.do(ctx, http.MethodPost, path, body, v)
+}
+
+func (c *Client) put(ctx context.Context, path string, body, v interface{}) error {
+	return c.do(ctx, http.MethodPut, path, body, v)
+}
+
+func (c *Client) delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil)
 }
 
-func (c *Client) delete(path string) error {
-	url, err := url.Parse(path)
+// do sends a request with body encoded as JSON, if not nil, and decodes the
+// response into v, if not nil.
+func (c *Client) do(ctx context.Context, method, path string, body, v interface{}) error {
+	ref, err := url.Parse(path)
 	if err != nil {
 		return fmt.Errorf("podio-go: failed to parse URL: %w", err)
 	}
-	req := &http.Request{
-		Method: "DELETE",
-		URL:    url,
+
+	var payload io.Reader
+	if body != nil {
+		buf := &bytes.Buffer{}
+		err = json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return fmt.Errorf("podio-go: could not encode params: %w", err)
+		}
+		payload = buf
 	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL.ResolveReference(ref).String(), payload)
+	if err != nil {
+		return fmt.Errorf("podio-go: failed to create %s request for %s: %w", method, path, err)
+	}
+
+	if body != nil {
+		req.Header.Set("content-type", "application/json")
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("podio-go: failed to DELETE %s: %w", path, err)
+		return fmt.Errorf("podio-go: failed to %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return responseError(resp, method, path)
+	}
+
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
 	}
 
-	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("podio-go: failed to DELETE %s: %s\nPayload: %s", path, resp.Status, string(output))
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("podio-go: failed to decode response: %w", err)
 	}
 
 	return nil
@@ -122,6 +259,11 @@ type oAuth2Request struct {
 	GrantType    string `json:"grant_type"`
 	Username     string `json:"username,omitempty"`
 	Password     string `json:"password,omitempty"`
+	AppID        string `json:"app_id,omitempty"`
+	AppToken     string `json:"app_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Code         string `json:"code,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 }
@@ -137,24 +279,43 @@ type oAuth2Token struct {
 	} `json:"ref"`
 }
 
-func (c *Client) doOAuthGrant(credentials oAuth2Request) (*oAuth2Token, error) {
+func (c *Client) doOAuthGrant(ctx context.Context, credentials oAuth2Request) (*oAuth2Token, error) {
 	body := url.Values{}
 	body.Set("grant_type", credentials.GrantType)
-	body.Set("username", credentials.Username)
-	body.Set("password", credentials.Password)
 	body.Set("client_id", credentials.ClientID)
 	body.Set("client_secret", credentials.ClientSecret)
 
+	switch credentials.GrantType {
+	case "password":
+		body.Set("username", credentials.Username)
+		body.Set("password", credentials.Password)
+	case "app":
+		body.Set("app_id", credentials.AppID)
+		body.Set("app_token", credentials.AppToken)
+	case "authorization_code":
+		body.Set("code", credentials.Code)
+		body.Set("redirect_uri", credentials.RedirectURI)
+	case "refresh_token":
+		body.Set("refresh_token", credentials.RefreshToken)
+	}
+
 	buf := bytes.NewBufferString(body.Encode())
 
-	resp, err := c.httpClient.Post("/oauth/token", "application/x-www-form-urlencoded", buf)
+	tokenURL := c.apiURL.ResolveReference(&url.URL{Path: "/oauth/token"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL.String(), buf)
 	if err != nil {
 		return nil, fmt.Errorf("podio-go: failed to request OAuth token: %w", err)
 	}
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to request OAuth token: %w", err)
+	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("podio-go: failed to request OAuth token: %s; body: %s", resp.Status, string(output))
+		return nil, fmt.Errorf("podio-go: failed to request OAuth token: %w", responseError(resp, http.MethodPost, tokenURL.Path))
 	}
 
 	token := &oAuth2Token{}
@@ -166,27 +327,235 @@ func (c *Client) doOAuthGrant(credentials oAuth2Request) (*oAuth2Token, error) {
 	return token, nil
 }
 
+// tokenRefreshWindow is how long before expiry a token is proactively refreshed.
+const tokenRefreshWindow = 60 * time.Second
+
 type authenticatedTransport struct {
-	apiToken string
-	apiURL   *url.URL
-	options  ClientOptions
+	options ClientOptions
+	next    http.RoundTripper
+
+	// grant performs an OAuth grant. Requests to /oauth/ are sent without
+	// authorization, so it is safe to call while holding mu.
+	grant func(context.Context, oAuth2Request) (*oAuth2Token, error)
+
+	mu    sync.Mutex
+	token *Token
+}
+
+func (a *authenticatedTransport) currentToken() *Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return nil
+	}
+
+	token := *a.token
+	return &token
+}
+
+func (a *authenticatedTransport) setToken(token *Token) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.storeToken(token)
+}
+
+// storeToken must be called with mu held.
+func (a *authenticatedTransport) storeToken(token *Token) error {
+	a.token = token
+
+	if a.options.TokenStore == nil {
+		return nil
+	}
+
+	if err := a.options.TokenStore.Save(token); err != nil {
+		return fmt.Errorf("podio-go: failed to save token: %w", err)
+	}
+
+	return nil
+}
+
+// accessToken returns a usable access token, refreshing it first if it is
+// about to expire.
+func (a *authenticatedTransport) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return "", nil
+	}
+
+	if a.token.expiresWithin(tokenRefreshWindow) {
+		// A token that has not quite expired is still worth sending if the
+		// refresh fails; the 401 path gets another chance at it.
+		if err := a.refresh(ctx); err != nil && a.token.expiresWithin(0) {
+			return "", err
+		}
+	}
+
+	return a.token.AccessToken, nil
+}
+
+// refreshAfterUnauthorized refreshes the token after a 401, unless another
+// goroutine already replaced the token that was rejected.
+func (a *authenticatedTransport) refreshAfterUnauthorized(ctx context.Context, rejected string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == nil {
+		return "", fmt.Errorf("podio-go: not authenticated")
+	}
+
+	if a.token.AccessToken == rejected {
+		if err := a.refresh(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return a.token.AccessToken, nil
+}
+
+// refresh must be called with mu held.
+func (a *authenticatedTransport) refresh(ctx context.Context) error {
+	// Hold the store's lock until the new token is saved, so clients in
+	// other processes wait for it rather than using the same refresh token.
+	if store, ok := a.options.TokenStore.(LockingTokenStore); ok {
+		unlock, err := store.Lock(ctx)
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to refresh OAuth token: %w", err)
+		}
+		defer unlock()
+	}
+
+	// Another client sharing the store may have refreshed already, in which
+	// case our refresh token is no longer valid and its token is used.
+	if a.options.TokenStore != nil {
+		stored, err := a.options.TokenStore.Load()
+		if err == nil && stored != nil && stored.AccessToken != a.token.AccessToken {
+			a.token = stored
+			if !stored.expiresWithin(tokenRefreshWindow) {
+				return nil
+			}
+		}
+	}
+
+	if a.token.RefreshToken == "" {
+		return fmt.Errorf("podio-go: access token expired and no refresh token is available")
+	}
+
+	oauth, err := a.grant(ctx, oAuth2Request{
+		GrantType:    "refresh_token",
+		RefreshToken: a.token.RefreshToken,
+		ClientID:     a.options.ApiKey,
+		ClientSecret: a.options.ApiSecret,
+	})
+	if err != nil {
+		return fmt.Errorf("podio-go: failed to refresh OAuth token: %w", err)
+	}
+
+	token := newToken(oauth)
+	if token.RefreshToken == "" {
+		token.RefreshToken = a.token.RefreshToken
+	}
+
+	a.options.Logger.Printf("podio-go: refreshed OAuth token")
+
+	// The new token is usable even if it could not be persisted.
+	if err := a.storeToken(token); err != nil {
+		a.options.Logger.Printf("%s", err)
+	}
+	return nil
 }
 
 func (a *authenticatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	if a.options.UserAgent != "" {
-		req.Header.Add("user-agent", a.options.UserAgent)
-	} else {
-		req.Header.Add("user-agent", "podio-go")
+	if strings.HasPrefix(req.URL.Path, "/oauth/") {
+		return a.next.RoundTrip(req)
+	}
+
+	apiToken, err := a.accessToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if apiToken == "" {
+		return a.next.RoundTrip(req)
+	}
+
+	// The request may need to be replayed after a refresh.
+	req, err = rewindable(req)
+	if err != nil {
+		return nil, err
 	}
 
-	if a.apiToken != "" {
-		req.Header.Add("authorization", "OAuth2 "+a.apiToken)
+	req.Header.Set("authorization", "OAuth2 "+apiToken)
+	resp, err := a.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	apiToken, err = a.refreshAfterUnauthorized(req.Context(), apiToken)
+	if err != nil {
+		// Surface the original 401 rather than the refresh failure.
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+
+	resp.Body.Close()
+	retry.Header.Set("authorization", "OAuth2 "+apiToken)
+	return a.next.RoundTrip(retry)
+}
+
+// userAgentTransport sets the user agent, and resolves relative requests
+// against the API URL.
+type userAgentTransport struct {
+	userAgent string
+	apiURL    *url.URL
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	if t.userAgent != "" {
+		req.Header.Set("user-agent", t.userAgent)
+	} else {
+		req.Header.Set("user-agent", "podio-go")
 	}
 
 	if req.URL.Host == "" {
-		req.URL.Scheme = a.apiURL.Scheme
-		req.URL.Host = a.apiURL.Host
+		req.URL.Scheme = t.apiURL.Scheme
+		req.URL.Host = t.apiURL.Host
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// rewindable returns a clone of req whose body can be read again through
+// GetBody, buffering the body if needed.
+func rewindable(req *http.Request) (*http.Request, error) {
+	req = req.Clone(req.Context())
+	if req.Body == nil || req.GetBody != nil {
+		return req, nil
+	}
+
+	payload, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to read request body: %w", err)
+	}
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(payload)), nil
 	}
+	req.Body, _ = req.GetBody()
 
-	return http.DefaultTransport.RoundTrip(req)
+	return req, nil
 }
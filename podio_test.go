@@ -0,0 +1,76 @@
+package podio_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func TestConcurrentRefresh(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{Config: podio.AppConfig{Name: "Deals"}})
+	client := srv.NewClient()
+	appID := strconv.Itoa(app.AppID)
+
+	srv.ExpireTokens()
+	before := len(srv.Requests())
+
+	const calls = 20
+	var wg sync.WaitGroup
+	errs := make([]error, calls)
+
+	for n := 0; n < calls; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if n%2 == 0 {
+				_, errs[n] = client.GetApplication(appID)
+				return
+			}
+			_, errs[n] = client.CreateField(appID, podio.CreateFieldParams{
+				Type:   podio.FieldTypeText,
+				Config: podio.FieldConfig{Label: fmt.Sprintf("Field %d", n)},
+			})
+		}(n)
+	}
+	wg.Wait()
+
+	for n, err := range errs {
+		if err != nil {
+			t.Errorf("call %d: %v", n, err)
+		}
+	}
+
+	grants := 0
+	for _, r := range srv.Requests()[before:] {
+		if r.Method == http.MethodPost && r.Path == "/oauth/token" {
+			grants++
+		}
+	}
+	if grants != 1 {
+		t.Errorf("refresh grants = %d, want 1", grants)
+	}
+
+	// The server rejects an empty body, so every field with its label means
+	// each POST was replayed intact after its 401.
+	stored, _ := srv.Application(app.AppID)
+	labels := map[string]bool{}
+	for _, field := range stored.Fields {
+		labels[field.Config.Label] = true
+	}
+	for n := 1; n < calls; n += 2 {
+		if label := fmt.Sprintf("Field %d", n); !labels[label] {
+			t.Errorf("field %q was not created", label)
+		}
+	}
+	if len(stored.Fields) != calls/2 {
+		t.Errorf("created %d fields, want %d", len(stored.Fields), calls/2)
+	}
+}
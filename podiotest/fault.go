@@ -0,0 +1,100 @@
+package podiotest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statusRateLimited is the non-standard status Podio uses when a rate limit is hit.
+const statusRateLimited = 420
+
+// Fault makes the server fail matching requests with an error response.
+type Fault struct {
+	// Method matches the request method. Empty matches every method.
+	Method string
+	// Path matches requests whose path starts with it. Empty matches every
+	// path, including the token endpoint.
+	Path string
+	// Status, Code and Description make up the error response.
+	Status      int
+	Code        string
+	Description string
+	// Header is added to the error response.
+	Header http.Header
+	// Times is the number of requests to fail before the fault is removed.
+	// Zero fails every matching request until ClearFaults is called.
+	Times int
+}
+
+// NotFound fails matching requests with 404.
+func NotFound(method, path string) Fault {
+	return Fault{Method: method, Path: path, Status: http.StatusNotFound, Code: "not_found", Description: "Object not found"}
+}
+
+// Unauthorized fails matching requests with 401, as for an invalid token.
+func Unauthorized(method, path string) Fault {
+	return Fault{Method: method, Path: path, Status: http.StatusUnauthorized, Code: "unauthorized", Description: "invalid_token"}
+}
+
+// RateLimit is the hourly quota RateLimited faults report.
+const RateLimit = 5000
+
+// RateLimited fails matching requests with Podio's 420 rate limit response.
+func RateLimited(method, path string, retryAfter time.Duration) Fault {
+	seconds := strconv.Itoa(int(retryAfter / time.Second))
+	return Fault{
+		Method:      method,
+		Path:        path,
+		Status:      statusRateLimited,
+		Code:        "rate_limit",
+		Description: "You have hit the rate limit. Please wait " + seconds + " seconds before trying again",
+		Header:      http.Header{"Retry-After": {seconds}, "X-Rate-Limit-Limit": {strconv.Itoa(RateLimit)}, "X-Rate-Limit-Remaining": {"0"}},
+	}
+}
+
+// InternalError fails matching requests with 500.
+func InternalError(method, path string) Fault {
+	return Fault{Method: method, Path: path, Status: http.StatusInternalServerError, Code: "unavailable", Description: "An internal error occurred"}
+}
+
+// Inject adds a fault. Faults are matched in the order they were added.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// fault returns the first fault matching r, consuming one of its times. s.mu
+// must be held.
+func (s *Server) fault(r *http.Request) *Fault {
+	for n, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:n:n], s.faults[n+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
@@ -0,0 +1,469 @@
+package podiotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kayteh/podio-go"
+)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+
+	if fault := s.fault(r); fault != nil {
+		for key, values := range fault.Header {
+			w.Header()[key] = values
+		}
+		writeError(w, fault.Status, fault.Code, fault.Description)
+		return
+	}
+
+	if r.URL.Path == "/oauth/token" {
+		s.serveToken(w, r)
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "expired_token")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch parts[0] {
+	case "org":
+		s.serveOrganizations(w, r, parts[1:])
+	case "space":
+		s.serveSpaces(w, r, parts[1:])
+	case "app":
+		s.serveApplications(w, r, parts[1:])
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	accessToken := strings.TrimPrefix(r.Header.Get("authorization"), "OAuth2 ")
+	t, ok := s.tokens[accessToken]
+	return ok && time.Now().Before(t.expiresAt)
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "Invalid token request")
+		return
+	}
+
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client", "Invalid client_id or client_secret")
+		return
+	}
+
+	var t *token
+	switch r.PostForm.Get("grant_type") {
+	case "password":
+		u, ok := s.users[r.PostForm.Get("username")]
+		if ok && u.password == r.PostForm.Get("password") {
+			t = &token{refType: "user", refID: u.id}
+		}
+	case "app":
+		appID, _ := strconv.Atoi(r.PostForm.Get("app_id"))
+		appToken, ok := s.appTokens[appID]
+		if ok && appToken == r.PostForm.Get("app_token") {
+			t = &token{refType: "app", refID: appID}
+		}
+	case "authorization_code":
+		if userID, ok := s.codes[r.PostForm.Get("code")]; ok {
+			delete(s.codes, r.PostForm.Get("code"))
+			t = &token{refType: "user", refID: userID}
+		}
+	case "refresh_token":
+		if previous, ok := s.refresh[r.PostForm.Get("refresh_token")]; ok {
+			delete(s.refresh, r.PostForm.Get("refresh_token"))
+			t = &token{refType: previous.refType, refID: previous.refID}
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "Unsupported grant type")
+		return
+	}
+
+	if t == nil {
+		writeError(w, http.StatusBadRequest, "invalid_grant", "Invalid credentials")
+		return
+	}
+
+	t.expiresAt = time.Now().Add(s.TokenLifetime)
+	accessToken, refreshToken := randomString(), randomString()
+	s.tokens[accessToken] = t
+	s.refresh[refreshToken] = t
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  accessToken,
+		"token_type":    "bearer",
+		"expires_in":    int(s.TokenLifetime / time.Second),
+		"refresh_token": refreshToken,
+		"ref":           map[string]interface{}{"type": t.refType, "id": t.refID},
+	})
+}
+
+func (s *Server) serveOrganizations(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet {
+		notFound(w)
+		return
+	}
+
+	switch {
+	case len(parts) == 0:
+		orgs := []*podio.Organization{}
+		for _, id := range sortedIDs(s.orgs) {
+			orgs = append(orgs, s.orgs[id])
+		}
+		writeJSON(w, http.StatusOK, orgs)
+	case len(parts) == 1 && parts[0] == "url":
+		for _, org := range s.orgs {
+			if org.URL == r.URL.Query().Get("url") {
+				writeJSON(w, http.StatusOK, org)
+				return
+			}
+		}
+		notFound(w)
+	case len(parts) == 1:
+		if org, ok := s.orgs[atoi(parts[0])]; ok {
+			writeJSON(w, http.StatusOK, org)
+			return
+		}
+		notFound(w)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveSpaces(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		params := podio.CreateSpaceParams{}
+		if !decode(w, r, &params) {
+			return
+		}
+		if _, ok := s.orgs[params.OrgID]; !ok {
+			notFound(w)
+			return
+		}
+
+		space := s.addSpace(&podio.Space{
+			Name:            params.Name,
+			OrgID:           params.OrgID,
+			Privacy:         params.Privacy,
+			AutoJoin:        params.AutoJoin,
+			PostOnNewApp:    params.PostOnNewApp,
+			PostOnNewMember: params.PostOnNewMember,
+		})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"space_id": space.ID, "url": space.URL})
+	case len(parts) == 1 && parts[0] == "url" && r.Method == http.MethodGet:
+		for _, space := range s.spaces {
+			if space.URL == r.URL.Query().Get("url") {
+				writeJSON(w, http.StatusOK, space)
+				return
+			}
+		}
+		notFound(w)
+	case len(parts) == 2 && parts[0] == "org" && r.Method == http.MethodGet:
+		orgID := atoi(parts[1])
+		if _, ok := s.orgs[orgID]; !ok {
+			notFound(w)
+			return
+		}
+
+		spaces := []*podio.Space{}
+		for _, id := range sortedIDs(s.spaces) {
+			if s.spaces[id].OrgID == orgID {
+				spaces = append(spaces, s.spaces[id])
+			}
+		}
+		writeJSON(w, http.StatusOK, spaces)
+	case len(parts) == 1:
+		space, ok := s.spaces[atoi(parts[0])]
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, space)
+		case http.MethodPut:
+			if decode(w, r, space) {
+				w.WriteHeader(http.StatusNoContent)
+			}
+		case http.MethodDelete:
+			delete(s.spaces, space.ID)
+			for id, app := range s.apps {
+				if app.SpaceID == space.ID {
+					delete(s.apps, id)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			notFound(w)
+		}
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveApplications(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		params := podio.CreateApplicationParams{}
+		if !decode(w, r, &params) {
+			return
+		}
+		if _, ok := s.spaces[params.SpaceID]; !ok {
+			notFound(w)
+			return
+		}
+
+		app := s.addApplication(&podio.Application{SpaceID: params.SpaceID, Config: params.Config, Fields: params.Fields})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"app_id": app.AppID})
+	case len(parts) == 2 && parts[0] == "space" && r.Method == http.MethodGet:
+		spaceID := atoi(parts[1])
+		if _, ok := s.spaces[spaceID]; !ok {
+			notFound(w)
+			return
+		}
+
+		apps := []*podio.Application{}
+		for _, id := range sortedIDs(s.apps) {
+			app := s.apps[id]
+			if app.SpaceID == spaceID && (app.Status == "active" || r.URL.Query().Get("include_inactive") == "true") {
+				apps = append(apps, app)
+			}
+		}
+		writeJSON(w, http.StatusOK, apps)
+	case len(parts) >= 1:
+		app, ok := s.apps[atoi(parts[0])]
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		if len(parts) == 1 {
+			s.serveApplication(w, r, app)
+			return
+		}
+
+		if parts[1] == "field" {
+			s.serveFields(w, r, app, parts[2:])
+			return
+		}
+
+		if parts[1] == "install" && len(parts) == 2 && r.Method == http.MethodPost {
+			s.installApplication(w, r, app)
+			return
+		}
+
+		notFound(w)
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) serveApplication(w http.ResponseWriter, r *http.Request, app *podio.Application) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, app)
+	case http.MethodPut:
+		params := podio.CreateApplicationParams{}
+		if !decode(w, r, &params) {
+			return
+		}
+
+		app.Config = params.Config
+		for _, field := range params.Fields {
+			if existing := fieldByID(app, field.FieldID); existing != nil {
+				existing.Config = field.Config
+				existing.Config.Settings = s.settings(existing.Type, field.Config.Settings)
+				continue
+			}
+			s.addField(app, field)
+		}
+		for _, field := range params.FieldsToDelete {
+			removeField(app, field.FieldID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(s.apps, app.AppID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+// installApplication copies app into another space, with new field ids.
+func (s *Server) installApplication(w http.ResponseWriter, r *http.Request, app *podio.Application) {
+	params := struct {
+		SpaceID int `json:"space_id"`
+	}{}
+	if !decode(w, r, &params) {
+		return
+	}
+	if _, ok := s.spaces[params.SpaceID]; !ok {
+		notFound(w)
+		return
+	}
+
+	installed := &podio.Application{
+		SpaceID:          params.SpaceID,
+		Config:           app.Config,
+		OriginalID:       app.AppID,
+		OriginalRevision: app.CurrentRevision,
+	}
+	for _, field := range app.Fields {
+		field.FieldID = 0
+		installed.Fields = append(installed.Fields, field)
+	}
+
+	installed = s.addApplication(installed)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"app_id": installed.AppID})
+}
+
+func (s *Server) serveFields(w http.ResponseWriter, r *http.Request, app *podio.Application, parts []string) {
+	if len(parts) == 0 {
+		if r.Method != http.MethodPost {
+			notFound(w)
+			return
+		}
+
+		params := podio.CreateFieldParams{}
+		if !decode(w, r, &params) {
+			return
+		}
+
+		field := s.addField(app, podio.Field{Type: params.Type, Config: params.Config})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"field_id": field.FieldID})
+		return
+	}
+
+	field := app.Field(parts[0])
+	if len(parts) > 1 || field == nil {
+		notFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, field)
+	case http.MethodPut:
+		config := podio.FieldConfig{}
+		if !decode(w, r, &config) {
+			return
+		}
+
+		config.Settings = s.settings(field.Type, config.Settings)
+		field.Config = config
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		removeField(app, field.FieldID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notFound(w)
+	}
+}
+
+func fieldByID(app *podio.Application, fieldID int) *podio.Field {
+	for n := range app.Fields {
+		if app.Fields[n].FieldID == fieldID {
+			return &app.Fields[n]
+		}
+	}
+
+	return nil
+}
+
+func removeField(app *podio.Application, fieldID int) {
+	for n := range app.Fields {
+		if app.Fields[n].FieldID == fieldID {
+			app.Fields = append(app.Fields[:n], app.Fields[n+1:]...)
+			return
+		}
+	}
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_value", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "Object not found")
+}
+
+func sortedIDs[T any](m map[int]T) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// slug turns a name into a url label or external id, e.g. "Due date" into
+// "due-date".
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	return b.String()
+}
+
+func randomString() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(buf)
+}
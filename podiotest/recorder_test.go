@@ -0,0 +1,190 @@
+package podiotest_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+// recordingClient returns a client sending calls to apiURL through rec.
+func recordingClient(t *testing.T, apiURL string, rec *podiotest.Recorder) *podio.Client {
+	t.Helper()
+
+	client, err := podio.NewClientWithOptions(
+		podio.WithCredentials(podiotest.ClientID, podiotest.ClientSecret),
+		podio.WithAPIURL(apiURL),
+		podio.WithAuthURL(apiURL),
+		podio.WithTransport(rec),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// record records a session against a fresh server into a cassette, and
+// returns its path, the id of the organization it used and the token the
+// client was issued.
+func record(t *testing.T) (string, int, *podio.Token) {
+	t.Helper()
+
+	srv := podiotest.NewServer()
+	defer srv.Close()
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	rec, err := podiotest.NewRecorder(path, podiotest.ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := recordingClient(t, srv.URL, rec)
+	if err := client.AuthenticateWithCredentials(podiotest.Username, podiotest.Password); err != nil {
+		t.Fatalf("AuthenticateWithCredentials: %v", err)
+	}
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatalf("GetOrganizations: %v", err)
+	}
+	if _, err := client.CreateSpace(podio.CreateSpaceParams{Name: "Sales", OrgID: org.ID}); err != nil {
+		t.Fatalf("CreateSpace: %v", err)
+	}
+
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	return path, org.ID, client.Token()
+}
+
+func replayer(t *testing.T, path string) (*podiotest.Recorder, *podio.Client) {
+	t.Helper()
+
+	rec, err := podiotest.NewRecorder(path, podiotest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec, recordingClient(t, "https://podio.invalid", rec)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path, orgID, _ := record(t)
+
+	rec, client := replayer(t, path)
+	if err := client.AuthenticateWithCredentials(podiotest.Username, podiotest.Password); err != nil {
+		t.Fatalf("replayed AuthenticateWithCredentials: %v", err)
+	}
+
+	orgs, err := client.GetOrganizations()
+	if err != nil {
+		t.Fatalf("replayed GetOrganizations: %v", err)
+	}
+	if len(*orgs) != 1 || (*orgs)[0].ID != orgID {
+		t.Errorf("replayed orgs = %+v, want org %d", *orgs, orgID)
+	}
+
+	space, err := client.CreateSpace(podio.CreateSpaceParams{Name: "Sales", OrgID: orgID})
+	if err != nil {
+		t.Fatalf("replayed CreateSpace: %v", err)
+	}
+	if space.Name != "Sales" {
+		t.Errorf("replayed space = %+v", space)
+	}
+
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("unused interactions = %+v, want none", unused)
+	}
+}
+
+func TestRecorderScrubsSecrets(t *testing.T) {
+	path, _, token := record(t)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette := string(data)
+
+	for _, secret := range []string{podiotest.ClientSecret, token.AccessToken, token.RefreshToken} {
+		if strings.Contains(cassette, secret) {
+			t.Errorf("cassette contains the secret %q", secret)
+		}
+	}
+
+	rec, err := podiotest.NewRecorder(path, podiotest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grant := rec.Unused()[0]
+	if grant.Request.URL != "/oauth/token" {
+		t.Fatalf("first interaction is %s, want the token request", grant.Request.URL)
+	}
+
+	form, err := url.ParseQuery(grant.Request.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"password", "client_secret"} {
+		if form.Get(key) != "REDACTED" {
+			t.Errorf("form %s = %q, want REDACTED", key, form.Get(key))
+		}
+	}
+	if form.Get("username") != podiotest.Username {
+		t.Errorf("form username = %q, want it kept", form.Get("username"))
+	}
+
+	response := map[string]interface{}{}
+	if err := json.Unmarshal(grant.Response.JSON, &response); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"access_token", "refresh_token"} {
+		if response[key] != "REDACTED" {
+			t.Errorf("response %s = %v, want REDACTED", key, response[key])
+		}
+	}
+}
+
+func TestReplayMatchesRequests(t *testing.T) {
+	path, orgID, _ := record(t)
+
+	rec, client := replayer(t, path)
+	client.AuthenticateWithCredentials(podiotest.Username, podiotest.Password)
+
+	_, err := client.CreateSpace(podio.CreateSpaceParams{Name: "Marketing", OrgID: orgID})
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("CreateSpace with another body: err = %v, want no recorded interaction", err)
+	}
+
+	if _, err := client.GetOrganization(strconv.Itoa(orgID)); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("unrecorded call: err = %v, want no recorded interaction", err)
+	}
+
+	var unused []string
+	for _, interaction := range rec.Unused() {
+		unused = append(unused, interaction.Request.Method+" "+interaction.Request.URL)
+	}
+	if want := []string{"GET /org/", "POST /space/"}; len(unused) < 2 || unused[0] != want[0] || unused[1] != want[1] {
+		t.Errorf("unused = %v, want %v first", unused, want)
+	}
+
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatalf("GetOrganizations: %v", err)
+	}
+	if _, err := client.GetOrganizations(); err == nil {
+		t.Error("an interaction was replayed twice")
+	}
+}
+
+func TestNewRecorderMissingCassette(t *testing.T) {
+	if _, err := podiotest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), podiotest.ModeReplay, nil); err == nil {
+		t.Error("loaded a missing cassette without error")
+	}
+}
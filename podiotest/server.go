@@ -0,0 +1,362 @@
+// Package podiotest provides an in-memory fake of the Podio API for testing
+// code built on podio-go without network access.
+//
+//	srv := podiotest.NewServer()
+//	defer srv.Close()
+//
+//	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+//	space := srv.AddSpace(podio.Space{Name: "Sales", OrgID: org.ID})
+//	client := srv.NewClient()
+//	app, err := client.CreateApplication(strconv.Itoa(space.ID), params)
+//
+// The server implements the OAuth token endpoint and the organization,
+// space, app and field endpoints of the API, including app installs. Faults
+// can be injected to test error handling.
+package podiotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/kayteh/podio-go"
+)
+
+const (
+	// ClientID and ClientSecret are the API key the server accepts.
+	ClientID     = "podiotest"
+	ClientSecret = "podiotest-secret"
+
+	// Username and Password are the credentials of the default user.
+	Username = "user@example.com"
+	Password = "password"
+)
+
+// Server is a fake Podio API server. All of its methods are safe for
+// concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// TokenLifetime is how long issued access tokens are valid. Defaults to
+	// 8 hours.
+	TokenLifetime time.Duration
+
+	mu        sync.Mutex
+	nextID    int
+	users     map[string]*user
+	appTokens map[int]string
+	codes     map[string]int
+	tokens    map[string]*token
+	refresh   map[string]*token
+	orgs      map[int]*podio.Organization
+	spaces    map[int]*podio.Space
+	apps      map[int]*podio.Application
+	faults    []*Fault
+	requests  []Request
+}
+
+type user struct {
+	id       int
+	password string
+}
+
+type token struct {
+	refType   string
+	refID     int
+	expiresAt time.Time
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+}
+
+// NewServer starts a server with the default user and no data.
+func NewServer() *Server {
+	s := &Server{
+		TokenLifetime: 8 * time.Hour,
+		nextID:        1000,
+		users:         map[string]*user{},
+		appTokens:     map[int]string{},
+		codes:         map[string]int{},
+		tokens:        map[string]*token{},
+		refresh:       map[string]*token{},
+		orgs:          map[int]*podio.Organization{},
+		spaces:        map[int]*podio.Space{},
+		apps:          map[int]*podio.Application{},
+	}
+
+	s.AddUser(Username, Password)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Options returns client options pointing at the server, with its API key.
+func (s *Server) Options() podio.ClientOptions {
+	return podio.ClientOptions{
+		ApiKey:    ClientID,
+		ApiSecret: ClientSecret,
+		ApiURL:    s.URL,
+		AuthURL:   s.URL,
+	}
+}
+
+// NewClient returns a client authenticated as the default user. It panics
+// if the client can't be created or authenticated.
+func (s *Server) NewClient(opts ...podio.Option) *podio.Client {
+	opts = append([]podio.Option{
+		podio.WithCredentials(ClientID, ClientSecret),
+		podio.WithAPIURL(s.URL),
+		podio.WithAuthURL(s.URL),
+	}, opts...)
+
+	client, err := podio.NewClientWithOptions(opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := client.AuthenticateWithCredentials(Username, Password); err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+// AddUser adds a user who can log in with the password grant, and returns
+// its id.
+func (s *Server) AddUser(username, password string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := &user{id: s.id(), password: password}
+	s.users[username] = u
+	return u.id
+}
+
+// AddAuthCode returns an authorization code the default user can exchange
+// once with the authorization_code grant.
+func (s *Server) AddAuthCode() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := randomString()
+	s.codes[code] = s.users[Username].id
+	return code
+}
+
+// ExpireTokens expires every access token issued so far, so the next call
+// with one is rejected with 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.tokens {
+		t.expiresAt = time.Time{}
+	}
+}
+
+// AddOrganization stores org, assigning it an id if it has none, and
+// returns the stored copy.
+func (s *Server) AddOrganization(org podio.Organization) *podio.Organization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if org.ID == 0 {
+		org.ID = s.id()
+	}
+	if org.URLLabel == "" {
+		org.URLLabel = slug(org.Name)
+	}
+	if org.URL == "" {
+		org.URL = "https://podio.com/" + org.URLLabel
+	}
+	if org.Status == "" {
+		org.Status = "active"
+	}
+
+	stored := clone(&org)
+	s.orgs[org.ID] = stored
+	return clone(stored)
+}
+
+// AddSpace stores space, assigning it an id if it has none, and returns the
+// stored copy.
+func (s *Server) AddSpace(space podio.Space) *podio.Space {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.addSpace(&space)
+	return clone(stored)
+}
+
+// AddApplication stores app and its fields, assigning ids and an app token
+// where missing, and returns the stored copy.
+func (s *Server) AddApplication(app podio.Application) *podio.Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.addApplication(&app)
+	return clone(stored)
+}
+
+// Organization returns the stored organization with the given id.
+func (s *Server) Organization(orgID int) (*podio.Organization, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org, ok := s.orgs[orgID]
+	return clone(org), ok
+}
+
+// Space returns the stored space with the given id.
+func (s *Server) Space(spaceID int) (*podio.Space, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	space, ok := s.spaces[spaceID]
+	return clone(space), ok
+}
+
+// Application returns the stored app with the given id.
+func (s *Server) Application(appID int) (*podio.Application, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.apps[appID]
+	return clone(app), ok
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// id returns a new id. s.mu must be held.
+func (s *Server) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) addSpace(space *podio.Space) *podio.Space {
+	if space.ID == 0 {
+		space.ID = s.id()
+	}
+	if space.URLLabel == "" {
+		space.URLLabel = slug(space.Name)
+	}
+	if space.URL == "" {
+		space.URL = "https://podio.com/" + space.URLLabel
+		if org, ok := s.orgs[space.OrgID]; ok {
+			space.URL = org.URL + "/" + space.URLLabel
+		}
+	}
+	if space.Privacy == "" {
+		space.Privacy = "closed"
+	}
+
+	stored := clone(space)
+	s.spaces[space.ID] = stored
+	return stored
+}
+
+func (s *Server) addApplication(app *podio.Application) *podio.Application {
+	if app.AppID == 0 {
+		app.AppID = s.id()
+	}
+	if app.Status == "" {
+		app.Status = "active"
+	}
+	if app.Token == "" {
+		app.Token = randomString()
+	}
+	if app.Config.Type == "" {
+		app.Config.Type = "standard"
+	}
+
+	stored := clone(app)
+	stored.Fields = nil
+	for _, field := range app.Fields {
+		s.addField(stored, field)
+	}
+
+	s.apps[app.AppID] = stored
+	s.appTokens[app.AppID] = app.Token
+	return stored
+}
+
+func (s *Server) addField(app *podio.Application, field podio.Field) *podio.Field {
+	if field.FieldID == 0 {
+		field.FieldID = s.id()
+	}
+	if field.ExternalID == "" {
+		field.ExternalID = slug(field.Config.Label)
+	}
+	if field.Status == "" {
+		field.Status = "active"
+	}
+	if field.Config.Delta == 0 {
+		field.Config.Delta = len(app.Fields)
+	}
+	field.Config.Settings = s.settings(field.Type, field.Config.Settings)
+
+	app.Fields = append(app.Fields, *clone(&field))
+	return &app.Fields[len(app.Fields)-1]
+}
+
+// settings normalizes field settings to their decoded JSON form, assigning
+// ids to new category options.
+func (s *Server) settings(fieldType string, settings interface{}) interface{} {
+	if settings == nil {
+		return nil
+	}
+
+	var decoded map[string]interface{}
+	raw, _ := json.Marshal(settings)
+	if json.Unmarshal(raw, &decoded) != nil {
+		return settings
+	}
+
+	if fieldType == podio.FieldTypeCategory {
+		options, _ := decoded["options"].([]interface{})
+		for _, option := range options {
+			option, ok := option.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if id, _ := option["id"].(float64); id == 0 {
+				option["id"] = s.id()
+			}
+			if option["status"] == nil {
+				option["status"] = "active"
+			}
+		}
+	}
+
+	return decoded
+}
+
+// clone deep copies v through JSON, so stored state never aliases values
+// owned by callers.
+func clone[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	copied := new(T)
+	if err := json.Unmarshal(raw, copied); err != nil {
+		panic(err)
+	}
+
+	return copied
+}
@@ -0,0 +1,385 @@
+package podiotest_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+// countRequests counts the requests srv received for method and path.
+func countRequests(srv *podiotest.Server, method, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func newClient(t *testing.T, srv *podiotest.Server) *podio.Client {
+	t.Helper()
+
+	client, err := podio.NewClientWithOptions(
+		podio.WithCredentials(podiotest.ClientID, podiotest.ClientSecret),
+		podio.WithAPIURL(srv.URL),
+		podio.WithAuthURL(srv.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestPasswordGrant(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+	if err := client.AuthenticateWithCredentials(podiotest.Username, podiotest.Password); err != nil {
+		t.Fatalf("AuthenticateWithCredentials: %v", err)
+	}
+
+	token := client.Token()
+	if token == nil || token.AccessToken == "" || token.RefreshToken == "" {
+		t.Fatalf("token = %+v, want access and refresh tokens", token)
+	}
+	if time.Until(token.ExpiresAt) < 7*time.Hour {
+		t.Errorf("token expires at %v, want about 8 hours from now", token.ExpiresAt)
+	}
+
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Errorf("GetOrganizations: %v", err)
+	}
+}
+
+func TestPasswordGrantRejectsWrongPassword(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	err := newClient(t, srv).AuthenticateWithCredentials(podiotest.Username, "wrong")
+	if !errors.Is(err, podio.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestAppGrant(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{Config: podio.AppConfig{Name: "Deals"}})
+
+	client := newClient(t, srv)
+	if err := client.AuthenticateAsApp(strconv.Itoa(app.AppID), app.Token); err != nil {
+		t.Fatalf("AuthenticateAsApp: %v", err)
+	}
+
+	got, err := client.GetApplication(strconv.Itoa(app.AppID))
+	if err != nil {
+		t.Fatalf("GetApplication: %v", err)
+	}
+	if got.Config.Name != "Deals" {
+		t.Errorf("name = %q, want Deals", got.Config.Name)
+	}
+}
+
+func TestAuthorizationCodeGrant(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := newClient(t, srv)
+	code := srv.AddAuthCode()
+
+	user, err := client.ExchangeCode(code, "https://example.com/callback")
+	if err != nil {
+		t.Fatalf("ExchangeCode: %v", err)
+	}
+	if _, err := user.GetOrganizations(); err != nil {
+		t.Errorf("GetOrganizations: %v", err)
+	}
+
+	if _, err := client.ExchangeCode(code, "https://example.com/callback"); !errors.Is(err, podio.ErrUnauthorized) {
+		t.Errorf("second exchange err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRefreshAfterExpiry(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	before := client.Token()
+
+	srv.ExpireTokens()
+
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatalf("GetOrganizations after expiry: %v", err)
+	}
+
+	after := client.Token()
+	if after.AccessToken == before.AccessToken || after.RefreshToken == before.RefreshToken {
+		t.Error("token was not refreshed")
+	}
+	if n := countRequests(srv, http.MethodPost, "/oauth/token"); n != 2 {
+		t.Errorf("token requests = %d, want 2", n)
+	}
+	if n := countRequests(srv, http.MethodGet, "/org/"); n != 2 {
+		t.Errorf("GET /org/ requests = %d, want the rejected one and its replay", n)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault podiotest.Fault
+		want  error
+	}{
+		{"not found", podiotest.NotFound(http.MethodGet, "/org/"), podio.ErrNotFound},
+		{"unauthorized", podiotest.Unauthorized(http.MethodGet, "/org/"), podio.ErrUnauthorized},
+		{"rate limited", podiotest.RateLimited(http.MethodGet, "/org/", time.Minute), podio.ErrRateLimited},
+		{"internal error", podiotest.InternalError(http.MethodGet, "/org/"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := podiotest.NewServer()
+			defer srv.Close()
+
+			client := srv.NewClient()
+			srv.Inject(tt.fault)
+
+			_, err := client.GetOrganizations()
+
+			var apiErr *podio.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *podio.APIError", err)
+			}
+			if apiErr.StatusCode != tt.fault.Status {
+				t.Errorf("status = %d, want %d", apiErr.StatusCode, tt.fault.Status)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitedRetryAfter(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	srv.Inject(podiotest.RateLimited("", "/app/", 90*time.Second))
+
+	_, err := client.GetApplication("1")
+
+	var rateLimitErr *podio.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("err = %v, want *podio.RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 90*time.Second {
+		t.Errorf("RetryAfter = %v, want 90s", rateLimitErr.RetryAfter)
+	}
+}
+
+func TestFaultTimes(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	fault := podiotest.InternalError(http.MethodGet, "/org/")
+	fault.Times = 1
+	srv.Inject(fault)
+
+	if _, err := client.GetOrganizations(); err == nil {
+		t.Fatal("first call succeeded, want the injected error")
+	}
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatalf("second call: %v, want the fault to be used up", err)
+	}
+}
+
+func TestClearFaults(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	srv.Inject(podiotest.InternalError("", ""))
+	srv.ClearFaults()
+
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatalf("GetOrganizations: %v", err)
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme Inc"})
+	client := srv.NewClient()
+
+	orgs, err := client.GetOrganizations()
+	if err != nil {
+		t.Fatalf("GetOrganizations: %v", err)
+	}
+	if len(*orgs) != 1 || (*orgs)[0].ID != org.ID {
+		t.Errorf("orgs = %+v, want [%d]", *orgs, org.ID)
+	}
+
+	got, err := client.GetOrganization(strconv.Itoa(org.ID))
+	if err != nil || got.Name != "Acme Inc" {
+		t.Errorf("GetOrganization = %+v, %v", got, err)
+	}
+
+	got, err = client.GetOrganizationBySlug("acme-inc")
+	if err != nil || got.ID != org.ID {
+		t.Errorf("GetOrganizationBySlug = %+v, %v", got, err)
+	}
+
+	if _, err := client.GetOrganization("1"); !errors.Is(err, podio.ErrNotFound) {
+		t.Errorf("missing org err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSpaceCRUD(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	org := srv.AddOrganization(podio.Organization{Name: "Acme"})
+	client := srv.NewClient()
+
+	space, err := client.CreateSpace(podio.CreateSpaceParams{Name: "Sales", OrgID: org.ID, Privacy: "open"})
+	if err != nil {
+		t.Fatalf("CreateSpace: %v", err)
+	}
+	if space.Name != "Sales" || space.OrgID != org.ID || space.Privacy != "open" {
+		t.Errorf("created space = %+v", space)
+	}
+
+	byURL, err := client.GetSpaceByURL(space.URL)
+	if err != nil || byURL.ID != space.ID {
+		t.Errorf("GetSpaceByURL = %+v, %v", byURL, err)
+	}
+
+	updated, err := client.UpdateSpace(strconv.Itoa(space.ID), podio.CreateSpaceParams{Name: "Sales EMEA"})
+	if err != nil || updated.Name != "Sales EMEA" {
+		t.Errorf("UpdateSpace = %+v, %v", updated, err)
+	}
+
+	spaces, err := client.GetWorkSpaces(strconv.Itoa(org.ID))
+	if err != nil || len(*spaces) != 1 {
+		t.Errorf("GetWorkSpaces = %+v, %v", spaces, err)
+	}
+
+	if err := client.DeleteSpace(strconv.Itoa(space.ID)); err != nil {
+		t.Fatalf("DeleteSpace: %v", err)
+	}
+	if _, err := client.GetSpace(strconv.Itoa(space.ID)); !errors.Is(err, podio.ErrNotFound) {
+		t.Errorf("deleted space err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestApplicationCRUD(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	client := srv.NewClient()
+	spaceID := strconv.Itoa(space.ID)
+
+	app, err := client.CreateApplication(spaceID, podio.CreateApplicationParams{
+		Config: podio.AppConfig{Name: "Deals", ItemName: "Deal"},
+		Fields: []podio.Field{
+			{Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Title"}},
+			{Type: podio.FieldTypeCategory, Config: podio.FieldConfig{
+				Label:    "Stage",
+				Settings: map[string]interface{}{"options": []interface{}{map[string]interface{}{"text": "New"}}},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+	if app.SpaceID != space.ID || app.Config.Name != "Deals" || len(app.Fields) != 2 {
+		t.Fatalf("created app = %+v", app)
+	}
+	if app.Field("title") == nil {
+		t.Error("title field has no external id derived from its label")
+	}
+	if options := app.Field("stage").CategoryOptions(); len(options) != 1 || options[0].ID == 0 {
+		t.Errorf("options = %+v, want an id assigned", options)
+	}
+
+	appID := strconv.Itoa(app.AppID)
+	updated, err := client.UpdateApplication(appID, podio.CreateApplicationParams{
+		Config:         podio.AppConfig{Name: "Opportunities"},
+		FieldsToDelete: []podio.FieldDelete{{FieldID: app.Field("stage").FieldID}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateApplication: %v", err)
+	}
+	if updated.Config.Name != "Opportunities" || len(updated.Fields) != 1 {
+		t.Errorf("updated app = %+v", updated)
+	}
+
+	apps, err := client.GetApplications(spaceID)
+	if err != nil || len(*apps) != 1 {
+		t.Errorf("GetApplications = %+v, %v", apps, err)
+	}
+
+	if err := client.DeleteApplication(appID); err != nil {
+		t.Fatalf("DeleteApplication: %v", err)
+	}
+	if _, err := client.GetApplication(appID); !errors.Is(err, podio.ErrNotFound) {
+		t.Errorf("deleted app err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFieldCRUD(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{Config: podio.AppConfig{Name: "Deals"}})
+	client := srv.NewClient()
+	appID := strconv.Itoa(app.AppID)
+
+	field, err := client.CreateField(appID, podio.CreateFieldParams{
+		Type:   podio.FieldTypeNumber,
+		Config: podio.FieldConfig{Label: "Amount", Required: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateField: %v", err)
+	}
+	if field.Type != podio.FieldTypeNumber || field.ExternalID != "amount" || !field.Config.Required {
+		t.Errorf("created field = %+v", field)
+	}
+
+	fieldID := strconv.Itoa(field.FieldID)
+	updated, err := client.UpdateField(appID, fieldID, podio.FieldConfig{Label: "Value"})
+	if err != nil || updated.Config.Label != "Value" {
+		t.Errorf("UpdateField = %+v, %v", updated, err)
+	}
+
+	byExternalID, err := client.GetField(appID, "amount")
+	if err != nil || byExternalID.FieldID != field.FieldID {
+		t.Errorf("GetField by external id = %+v, %v", byExternalID, err)
+	}
+
+	if err := client.DeleteField(appID, fieldID, true); err != nil {
+		t.Fatalf("DeleteField: %v", err)
+	}
+	if _, err := client.GetField(appID, fieldID); !errors.Is(err, podio.ErrNotFound) {
+		t.Errorf("deleted field err = %v, want ErrNotFound", err)
+	}
+
+	stored, _ := srv.Application(app.AppID)
+	if len(stored.Fields) != 0 {
+		t.Errorf("stored fields = %+v, want none", stored.Fields)
+	}
+}
@@ -0,0 +1,184 @@
+package podio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the API quota as last reported by Podio.
+type RateLimit struct {
+	// Limit is the number of calls allowed in the current hour.
+	Limit int
+	// Remaining is the number of calls left in the current hour.
+	Remaining int
+	// UpdatedAt is when Podio last reported the quota. It is zero until the
+	// first call that reports it.
+	UpdatedAt time.Time
+}
+
+// RateLimitThrottle slows calls down as the remaining quota runs low, rather
+// than running into the limit halfway through a batch of work.
+type RateLimitThrottle struct {
+	// Threshold is the remaining quota at which throttling starts.
+	Threshold int
+	// MaxDelay is the delay before each call once the quota is exhausted. The
+	// delay grows linearly from zero at Threshold to MaxDelay.
+	MaxDelay time.Duration
+}
+
+// RateLimitError is returned when Podio rejects a call because the rate limit
+// was hit. errors.Is(err, ErrRateLimited) also matches it.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is how long to wait before calling again.
+	RetryAfter time.Duration
+	// RetryAt is when it is safe to call again.
+	RetryAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", e.APIError.Error(), e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
+
+// defaultRetryAfter is used when Podio doesn't say how long to wait; limits
+// are per hour.
+const defaultRetryAfter = time.Hour
+
+var retryAfterDescription = regexp.MustCompile(`wait (\d+) seconds`)
+
+func newRateLimitError(apiErr *APIError, header http.Header) *RateLimitError {
+	retryAfter := defaultRetryAfter
+
+	if seconds, err := strconv.Atoi(header.Get("retry-after")); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	} else if match := retryAfterDescription.FindStringSubmatch(apiErr.Description); match != nil {
+		seconds, _ := strconv.Atoi(match[1])
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return &RateLimitError{
+		APIError:   apiErr,
+		RetryAfter: retryAfter,
+		RetryAt:    time.Now().Add(retryAfter),
+	}
+}
+
+// responseError reads an error response, as a *RateLimitError if the rate
+// limit was hit. The body is not closed.
+func responseError(resp *http.Response, method, path string) error {
+	apiErr := newAPIError(resp, method, path)
+	if apiErr.Is(ErrRateLimited) {
+		return newRateLimitError(apiErr, resp.Header)
+	}
+
+	return apiErr
+}
+
+// RateLimit returns the API quota as of the last call.
+func (c *Client) RateLimit() RateLimit {
+	return c.limits.current()
+}
+
+type rateLimiter struct {
+	throttle *RateLimitThrottle
+	logger   Logger
+
+	mu    sync.Mutex
+	limit RateLimit
+}
+
+func (r *rateLimiter) current() RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.limit
+}
+
+// update records the quota reported in a response's headers, if any.
+func (r *rateLimiter) update(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("x-rate-limit-limit"))
+	if err != nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(header.Get("x-rate-limit-remaining"))
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// wait delays a call according to the throttle, if the quota is running low.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	delay := r.delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	r.logger.Printf("podio-go: rate limit quota is low, throttling call by %s", delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *rateLimiter) delay() time.Duration {
+	if r.throttle == nil || r.throttle.Threshold <= 0 {
+		return 0
+	}
+
+	limit := r.current()
+	if limit.UpdatedAt.IsZero() || limit.Remaining >= r.throttle.Threshold {
+		return 0
+	}
+
+	used := r.throttle.Threshold - limit.Remaining
+	if used > r.throttle.Threshold {
+		used = r.throttle.Threshold
+	}
+
+	return r.throttle.MaxDelay * time.Duration(used) / time.Duration(r.throttle.Threshold)
+}
+
+// rateLimitTransport throttles calls by, and keeps track of, the rate limit.
+type rateLimitTransport struct {
+	limits *rateLimiter
+	next   http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limits.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.limits.update(resp.Header)
+	return resp, nil
+}
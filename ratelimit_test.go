@@ -0,0 +1,97 @@
+package podio_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{Config: podio.AppConfig{Name: "Deals"}})
+	client := srv.NewClient()
+	srv.Inject(podiotest.RateLimited(http.MethodGet, "/app/", 30*time.Second))
+
+	_, err := client.GetApplication(strconv.Itoa(app.AppID))
+
+	var limitErr *podio.RateLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, podio.ErrRateLimited) {
+		t.Fatalf("err = %v, want a RateLimitError", err)
+	}
+	if limitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %s, want 30s from Retry-After", limitErr.RetryAfter)
+	}
+
+	limit := client.RateLimit()
+	if limit.Limit != podiotest.RateLimit || limit.Remaining != 0 || limit.UpdatedAt.IsZero() {
+		t.Errorf("RateLimit = %+v, want %d calls with none remaining", limit, podiotest.RateLimit)
+	}
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault podiotest.Fault
+		want  time.Duration
+	}{
+		{
+			"description",
+			podiotest.Fault{Status: 420, Code: "rate_limit", Description: "You have hit the rate limit. Please wait 12 seconds before trying again"},
+			12 * time.Second,
+		},
+		{
+			"too many requests",
+			podiotest.Fault{Status: http.StatusTooManyRequests, Code: "too_many_requests"},
+			time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := podiotest.NewServer()
+			defer srv.Close()
+
+			client := srv.NewClient()
+			tt.fault.Method, tt.fault.Path = http.MethodGet, "/org/"
+			srv.Inject(tt.fault)
+
+			_, err := client.GetOrganizations()
+
+			var limitErr *podio.RateLimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("err = %v, want a RateLimitError", err)
+			}
+			if limitErr.RetryAfter != tt.want {
+				t.Errorf("RetryAfter = %s, want %s", limitErr.RetryAfter, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitedTokenRequest(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	srv.Inject(podiotest.RateLimited(http.MethodPost, "/oauth/token", 30*time.Second))
+
+	err := client.AuthenticateWithCredentials(podiotest.Username, podiotest.Password)
+
+	var limitErr *podio.RateLimitError
+	if !errors.As(err, &limitErr) || !errors.Is(err, podio.ErrRateLimited) {
+		t.Fatalf("err = %v, want a RateLimitError", err)
+	}
+	if limitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %s, want 30s", limitErr.RetryAfter)
+	}
+	if limit := client.RateLimit(); limit.Limit != podiotest.RateLimit || limit.Remaining != 0 {
+		t.Errorf("RateLimit = %+v, want the quota from the token response", limit)
+	}
+}
@@ -0,0 +1,153 @@
+package podio
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed calls are retried. GET, PUT and DELETE
+// calls are idempotent and retried by default; POST calls, which create
+// things in Podio, are only retried when RetryPOST is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It doubles with
+	// every attempt, and a random jitter of up to the full delay is applied.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// RetryStatusCodes are the response statuses that are retried. Network
+	// errors are always retried.
+	RetryStatusCodes []int
+	// RetryPOST allows POST calls to be retried as well.
+	RetryPOST bool
+}
+
+// DefaultRetryPolicy returns a policy retrying transient errors three times.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		RetryStatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p *RetryPolicy) retriesMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPOST
+	}
+
+	return false
+}
+
+func (p *RetryPolicy) retriesStatus(status int) bool {
+	for _, code := range p.RetryStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+type retryTransport struct {
+	policy *RetryPolicy
+	logger Logger
+	next   http.RoundTripper
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.policy.MaxAttempts <= 1 || !t.policy.retriesMethod(req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	// Every attempt needs the full body.
+	req, err := rewindable(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("podio-go: failed to rewind request body: %w", err)
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		if attempt >= t.policy.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		if err == nil && !t.policy.retriesStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := t.policy.backoff(attempt)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		}
+
+		if resp != nil {
+			reason = resp.Status
+
+			if seconds, err := strconv.Atoi(resp.Header.Get("retry-after")); err == nil {
+				if retryAfter := time.Duration(seconds) * time.Second; retryAfter > delay && (t.policy.MaxBackoff <= 0 || retryAfter <= t.policy.MaxBackoff) {
+					delay = retryAfter
+				}
+			}
+
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		t.logger.Printf("podio-go: retrying %s %s in %s after attempt %d failed: %s", req.Method, req.URL.Path, delay, attempt, reason)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package podio_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func retryPolicy(retryPOST bool) *podio.RetryPolicy {
+	policy := podio.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = time.Millisecond
+	policy.RetryPOST = retryPOST
+	return policy
+}
+
+func failTwice(srv *podiotest.Server, method, path string) {
+	fault := podiotest.InternalError(method, path)
+	fault.Times = 2
+	srv.Inject(fault)
+}
+
+func TestRetryPOSTResendsBody(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	client := srv.NewClient(podio.WithRetryPolicy(retryPolicy(true)))
+	failTwice(srv, http.MethodPost, "/app")
+
+	app, err := client.CreateApplication(strconv.Itoa(space.ID), podio.CreateApplicationParams{
+		Config: podio.AppConfig{Name: "Deals", ItemName: "Deal"},
+		Fields: []podio.Field{{Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Title"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+	if app.Config.Name != "Deals" || app.Config.ItemName != "Deal" || len(app.Fields) != 1 {
+		t.Errorf("created app = %+v, want the full body", app)
+	}
+
+	failTwice(srv, http.MethodPost, "/app/")
+	field, err := client.CreateField(strconv.Itoa(app.AppID), podio.CreateFieldParams{
+		Type:   podio.FieldTypeNumber,
+		Config: podio.FieldConfig{Label: "Amount", Required: true},
+	})
+	if err != nil {
+		t.Fatalf("CreateField: %v", err)
+	}
+	if field.Type != podio.FieldTypeNumber || field.Config.Label != "Amount" || !field.Config.Required {
+		t.Errorf("created field = %+v, want the full body", field)
+	}
+}
+
+func TestRetryPUTResendsBody(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{
+		Config: podio.AppConfig{Name: "Deals"},
+		Fields: []podio.Field{{Type: podio.FieldTypeText, ExternalID: "title", Config: podio.FieldConfig{Label: "Title"}}},
+	})
+	client := srv.NewClient(podio.WithRetryPolicy(retryPolicy(false)))
+	failTwice(srv, http.MethodPut, "/app/")
+
+	appID, fieldID := strconv.Itoa(app.AppID), strconv.Itoa(app.Fields[0].FieldID)
+	if _, err := client.UpdateField(appID, fieldID, podio.FieldConfig{Label: "Name", Description: "Deal name"}); err != nil {
+		t.Fatalf("UpdateField: %v", err)
+	}
+
+	stored, _ := srv.Application(app.AppID)
+	if config := stored.Fields[0].Config; config.Label != "Name" || config.Description != "Deal name" {
+		t.Errorf("stored config = %+v, want the full body", config)
+	}
+}
+
+func TestRetrySkipsPOSTByDefault(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	space := srv.AddSpace(podio.Space{Name: "Sales"})
+	client := srv.NewClient(podio.WithRetryPolicy(retryPolicy(false)))
+	failTwice(srv, http.MethodPost, "/app")
+
+	_, err := client.CreateApplication(strconv.Itoa(space.ID), podio.CreateApplicationParams{Config: podio.AppConfig{Name: "Deals"}})
+
+	var apiErr *podio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("err = %v, want the first 500", err)
+	}
+
+	posts := 0
+	for _, r := range srv.Requests() {
+		if r.Method == http.MethodPost && r.Path == "/app" {
+			posts++
+		}
+	}
+	if posts != 1 {
+		t.Errorf("POST /app requests = %d, want 1", posts)
+	}
+}
@@ -0,0 +1,539 @@
+package podio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AppSpec describes an app as kept in version control, as YAML or JSON, with
+// the JSON attribute names of the API. Fields are
+// keyed by external id, and their order in the spec is their order in the
+// app.
+type AppSpec struct {
+	// AppID is the id of the app the spec describes.
+	AppID int `json:"app_id"`
+	// Config is the configuration of the app.
+	Config AppConfig `json:"config"`
+	// Fields are the active fields of the app, in order.
+	Fields []FieldSpec `json:"fields"`
+}
+
+// FieldSpec describes a field of an app. Podio derives the external id of
+// new fields from their label, so fields added to a spec should use the
+// external id their label will produce.
+type FieldSpec struct {
+	ExternalID string `json:"external_id"`
+	Type       string `json:"type"`
+	// Config is the configuration of the field. Delta is ignored in favor
+	// of the field's position in the spec, and only the settings present
+	// are compared with the app.
+	Config FieldConfig `json:"config"`
+}
+
+// NewAppSpec returns the spec of app as it currently is. Responsible users
+// of tasks are reduced to their user ids.
+func NewAppSpec(app *Application) *AppSpec {
+	spec := &AppSpec{AppID: app.AppID, Config: app.Config, Fields: []FieldSpec{}}
+	spec.Config.Tasks = taskUserIDs(app.Config.Tasks)
+
+	fields := activeFields(app)
+	for _, field := range fields {
+		config := field.Config
+		config.Delta = 0
+		spec.Fields = append(spec.Fields, FieldSpec{ExternalID: field.ExternalID, Type: field.Type, Config: config})
+	}
+
+	return spec
+}
+
+// LoadAppSpec reads a spec from path. Files ending in .yaml or .yml are
+// read as YAML, others as JSON.
+func LoadAppSpec(path string) (*AppSpec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to read app spec: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// Go through JSON so specs use the JSON attribute names.
+		var v interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("podio-go: failed to decode app spec %s: %w", path, err)
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("podio-go: failed to decode app spec %s: %w", path, err)
+		}
+	}
+
+	spec := &AppSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("podio-go: failed to decode app spec %s: %w", path, err)
+	}
+
+	return spec, nil
+}
+
+// SchemaPlan is the set of changes that makes an app match its spec.
+type SchemaPlan struct {
+	AppID int
+	// Config lists the changes to the app configuration, and NewConfig is
+	// the configuration to apply if there are any.
+	Config    []Change
+	NewConfig AppConfig
+	// Creates are the fields to create.
+	Creates []FieldSpec
+	// Updates are the fields to update.
+	Updates []FieldUpdate
+	// Deletes are the fields to delete, along with their values.
+	Deletes []Field
+}
+
+// FieldUpdate is a change to an existing field.
+type FieldUpdate struct {
+	FieldID    int
+	ExternalID string
+	Changes    []Change
+	// Config is the configuration to apply.
+	Config FieldConfig
+}
+
+// Change is a changed attribute, e.g. "label" or "settings.options".
+type Change struct {
+	Attribute string
+	From      interface{}
+	To        interface{}
+}
+
+// Empty reports whether the app already matches its spec.
+func (p *SchemaPlan) Empty() bool {
+	return len(p.Config) == 0 && len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// Plan diffs app against spec. It fails if the spec is invalid, e.g. has a
+// new field without a type, or changes the type of a field, which Podio
+// doesn't support. Field deltas are only
+// updated when the spec orders the fields differently than the app.
+func Plan(app *Application, spec *AppSpec) (*SchemaPlan, error) {
+	plan := &SchemaPlan{AppID: app.AppID, NewConfig: spec.Config}
+	plan.Config = diffAppConfig(app.Config, spec.Config)
+
+	fields := activeFields(app)
+	current := map[string]*Field{}
+	for _, field := range fields {
+		field := field
+		current[field.ExternalID] = &field
+	}
+
+	seen := map[string]bool{}
+	for n, fieldSpec := range spec.Fields {
+		if fieldSpec.ExternalID == "" {
+			return nil, fmt.Errorf("podio-go: field %d of the spec has no external_id", n)
+		}
+		if seen[fieldSpec.ExternalID] {
+			return nil, fmt.Errorf("podio-go: field %q is in the spec twice", fieldSpec.ExternalID)
+		}
+		seen[fieldSpec.ExternalID] = true
+
+		field, ok := current[fieldSpec.ExternalID]
+		if !ok && fieldSpec.Type == "" {
+			return nil, fmt.Errorf("podio-go: new field %q of the spec has no type", fieldSpec.ExternalID)
+		}
+		if ok && fieldSpec.Type != "" && fieldSpec.Type != field.Type {
+			return nil, fmt.Errorf("podio-go: field %q can't change type from %s to %s, give it a new external_id instead", fieldSpec.ExternalID, field.Type, fieldSpec.Type)
+		}
+	}
+
+	// Unless the spec reorders the fields, existing fields keep their delta
+	// and new fields are added after them.
+	renumber := reorders(fields, spec)
+	next := 0
+	for _, field := range fields {
+		if field.Config.Delta >= next {
+			next = field.Config.Delta + 1
+		}
+	}
+
+	for n, fieldSpec := range spec.Fields {
+		field, ok := current[fieldSpec.ExternalID]
+
+		switch {
+		case renumber:
+			fieldSpec.Config.Delta = n
+		case ok:
+			fieldSpec.Config.Delta = field.Config.Delta
+		default:
+			fieldSpec.Config.Delta = next
+			next++
+		}
+
+		if !ok {
+			plan.Creates = append(plan.Creates, fieldSpec)
+			continue
+		}
+
+		if changes := diffFieldConfig(field.Config, fieldSpec.Config); len(changes) > 0 {
+			config := fieldSpec.Config
+			config.Settings = mergeSettings(field.Config.Settings, fieldSpec.Config.Settings)
+			plan.Updates = append(plan.Updates, FieldUpdate{
+				FieldID:    field.FieldID,
+				ExternalID: field.ExternalID,
+				Changes:    changes,
+				Config:     config,
+			})
+		}
+	}
+
+	for _, field := range activeFields(app) {
+		if !seen[field.ExternalID] {
+			plan.Deletes = append(plan.Deletes, field)
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanSchema fetches the app of spec and diffs it against spec.
+func (c *Client) PlanSchema(spec *AppSpec) (*SchemaPlan, error) {
+	return c.PlanSchemaContext(context.Background(), spec)
+}
+
+func (c *Client) PlanSchemaContext(ctx context.Context, spec *AppSpec) (*SchemaPlan, error) {
+	app, err := c.GetApplicationContext(ctx, strconv.Itoa(spec.AppID))
+	if err != nil {
+		return nil, err
+	}
+
+	return Plan(app, spec)
+}
+
+// ApplyOptions control how a plan is applied.
+type ApplyOptions struct {
+	// DeleteValues must be set to apply plans that delete fields, as the
+	// values of deleted fields are deleted with them.
+	DeleteValues bool
+}
+
+// ErrDeleteValues is returned when applying a plan that deletes fields
+// without ApplyOptions.DeleteValues.
+var ErrDeleteValues = fmt.Errorf("podio-go: plan deletes fields and their values, set ApplyOptions.DeleteValues to apply it")
+
+// ApplySchema applies plan. The app configuration is updated first, then
+// fields are created, updated and deleted. It stops at the first failure,
+// leaving the changes made so far in place.
+func (c *Client) ApplySchema(plan *SchemaPlan, options ApplyOptions) error {
+	return c.ApplySchemaContext(context.Background(), plan, options)
+}
+
+func (c *Client) ApplySchemaContext(ctx context.Context, plan *SchemaPlan, options ApplyOptions) error {
+	if len(plan.Deletes) > 0 && !options.DeleteValues {
+		return ErrDeleteValues
+	}
+
+	appID := strconv.Itoa(plan.AppID)
+
+	if len(plan.Config) > 0 {
+		_, err := c.UpdateApplicationContext(ctx, appID, CreateApplicationParams{Config: plan.NewConfig})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, field := range plan.Creates {
+		_, err := c.CreateFieldContext(ctx, appID, CreateFieldParams{Type: field.Type, Config: field.Config})
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to create field %q: %w", field.ExternalID, err)
+		}
+	}
+
+	for _, update := range plan.Updates {
+		_, err := c.UpdateFieldContext(ctx, appID, strconv.Itoa(update.FieldID), update.Config)
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to update field %q: %w", update.ExternalID, err)
+		}
+	}
+
+	for _, field := range plan.Deletes {
+		err := c.DeleteFieldContext(ctx, appID, strconv.Itoa(field.FieldID), true)
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to delete field %q: %w", field.ExternalID, err)
+		}
+	}
+
+	return nil
+}
+
+// activeFields returns the fields of app that aren't deleted, ordered by
+// delta.
+func activeFields(app *Application) []Field {
+	var fields []Field
+	for _, field := range app.Fields {
+		if field.Status != "deleted" {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Config.Delta < fields[j].Config.Delta
+	})
+
+	return fields
+}
+
+// reorders reports whether spec orders the fields of the app, ordered by
+// delta, differently. Fields new to the app only reorder it when they come
+// before an existing field.
+func reorders(fields []Field, spec *AppSpec) bool {
+	inSpec := map[string]bool{}
+	for _, fieldSpec := range spec.Fields {
+		inSpec[fieldSpec.ExternalID] = true
+	}
+
+	var order []string
+	for _, field := range fields {
+		if inSpec[field.ExternalID] {
+			order = append(order, field.ExternalID)
+		}
+	}
+
+	n := 0
+	for _, fieldSpec := range spec.Fields {
+		if n == len(order) {
+			return false
+		}
+		if fieldSpec.ExternalID != order[n] {
+			return true
+		}
+		n++
+	}
+
+	return false
+}
+
+// diffAppConfig compares app configurations, comparing the responsible
+// users of tasks by user id only.
+func diffAppConfig(current, spec AppConfig) []Change {
+	current.Tasks = taskUserIDs(current.Tasks)
+	spec.Tasks = taskUserIDs(spec.Tasks)
+
+	return diffAttributes("", current, spec)
+}
+
+func diffFieldConfig(current, spec FieldConfig) []Change {
+	specSettings, _ := generic(spec.Settings).(map[string]interface{})
+	currentSettings, _ := generic(current.Settings).(map[string]interface{})
+	currentDelta, specDelta := current.Delta, spec.Delta
+	current.Settings, spec.Settings = nil, nil
+	current.Delta, spec.Delta = 0, 0
+
+	changes := diffAttributes("", current, spec)
+	if currentDelta != specDelta {
+		changes = append(changes, Change{Attribute: "delta", From: currentDelta, To: specDelta})
+	}
+
+	if currentSettings == nil {
+		currentSettings = map[string]interface{}{}
+	}
+
+	for _, key := range sortedKeys(specSettings) {
+		if !subset(specSettings[key], currentSettings[key]) {
+			changes = append(changes, Change{Attribute: "settings." + key, From: currentSettings[key], To: specSettings[key]})
+		}
+	}
+
+	return changes
+}
+
+// diffAttributes compares the JSON attributes of two values of the same
+// type.
+func diffAttributes(prefix string, current, spec interface{}) []Change {
+	currentAttributes, _ := generic(current).(map[string]interface{})
+	specAttributes, _ := generic(spec).(map[string]interface{})
+
+	keys := map[string]bool{}
+	for key := range currentAttributes {
+		keys[key] = true
+	}
+	for key := range specAttributes {
+		keys[key] = true
+	}
+
+	var changes []Change
+	for _, key := range sortedKeys(keys) {
+		if !reflect.DeepEqual(currentAttributes[key], specAttributes[key]) {
+			changes = append(changes, Change{Attribute: prefix + key, From: currentAttributes[key], To: specAttributes[key]})
+		}
+	}
+
+	return changes
+}
+
+// subset reports whether every value in spec is also in current. Deleted
+// entries of lists, such as deleted category options, are ignored.
+func subset(spec, current interface{}) bool {
+	switch spec := spec.(type) {
+	case map[string]interface{}:
+		current, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range spec {
+			if !subset(value, current[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		current, ok := current.([]interface{})
+		if !ok {
+			return false
+		}
+		current = withoutDeleted(current)
+		if len(spec) != len(current) {
+			return false
+		}
+		for n := range spec {
+			if !subset(spec[n], current[n]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(spec, current)
+}
+
+func withoutDeleted(values []interface{}) []interface{} {
+	var active []interface{}
+	for _, value := range values {
+		if entry, ok := value.(map[string]interface{}); ok && entry["status"] == "deleted" {
+			continue
+		}
+		active = append(active, value)
+	}
+
+	return active
+}
+
+// mergeSettings overlays the settings of a spec on the current settings of
+// a field. Category options in the spec without an id get the id of the
+// current option with the same text, so their values are kept.
+func mergeSettings(current, spec interface{}) interface{} {
+	specSettings, _ := generic(spec).(map[string]interface{})
+	if specSettings == nil {
+		return current
+	}
+
+	merged, _ := generic(current).(map[string]interface{})
+	if merged == nil {
+		merged = map[string]interface{}{}
+	}
+
+	for key, value := range specSettings {
+		if key == "options" {
+			value = matchOptions(merged[key], value)
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func matchOptions(current, spec interface{}) interface{} {
+	currentOptions, _ := current.([]interface{})
+	specOptions, ok := spec.([]interface{})
+	if !ok {
+		return spec
+	}
+
+	for _, option := range specOptions {
+		option, ok := option.(map[string]interface{})
+		if !ok || option["id"] != nil {
+			continue
+		}
+
+		for _, existing := range currentOptions {
+			existing, ok := existing.(map[string]interface{})
+			if ok && existing["text"] == option["text"] && existing["status"] != "deleted" {
+				option["id"] = existing["id"]
+				break
+			}
+		}
+	}
+
+	return specOptions
+}
+
+// generic converts v to its generic JSON form, so values read from Podio
+// and values from a spec compare alike.
+func generic(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var decoded interface{}
+	json.Unmarshal(data, &decoded)
+	return decoded
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String renders the plan as a human readable diff.
+func (p *SchemaPlan) String() string {
+	var b strings.Builder
+
+	for _, change := range p.Config {
+		fmt.Fprintf(&b, "~ config.%s\n", change)
+	}
+
+	for _, field := range p.Creates {
+		fmt.Fprintf(&b, "+ field %s (%s)\n", field.ExternalID, field.Type)
+	}
+
+	for _, update := range p.Updates {
+		fmt.Fprintf(&b, "~ field %s\n", update.ExternalID)
+		for _, change := range update.Changes {
+			fmt.Fprintf(&b, "    %s\n", change)
+		}
+	}
+
+	for _, field := range p.Deletes {
+		fmt.Fprintf(&b, "- field %s (%s)\n", field.ExternalID, field.Type)
+	}
+
+	return b.String()
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Attribute, formatChangeValue(c.From), formatChangeValue(c.To))
+}
+
+func formatChangeValue(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
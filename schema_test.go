@@ -0,0 +1,152 @@
+package podio_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+)
+
+func schemaApp() *podio.Application {
+	return &podio.Application{
+		AppID:  1,
+		Config: podio.AppConfig{Name: "Deals"},
+		Fields: []podio.Field{
+			{FieldID: 10, ExternalID: "title", Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Title", Delta: 1}},
+			{FieldID: 11, ExternalID: "amount", Type: podio.FieldTypeNumber, Config: podio.FieldConfig{Label: "Amount", Delta: 3}},
+		},
+	}
+}
+
+func TestPlanNewAppSpecIsEmpty(t *testing.T) {
+	app := schemaApp()
+
+	plan, err := podio.Plan(app, podio.NewAppSpec(app))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan of an unchanged app:\n%s", plan)
+	}
+}
+
+func TestPlanAppendKeepsDeltas(t *testing.T) {
+	app := schemaApp()
+	spec := podio.NewAppSpec(app)
+	spec.Fields = append(spec.Fields, podio.FieldSpec{ExternalID: "stage", Type: podio.FieldTypeText, Config: podio.FieldConfig{Label: "Stage"}})
+
+	plan, err := podio.Plan(app, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Updates) != 0 {
+		t.Errorf("updates = %+v, want none", plan.Updates)
+	}
+	if len(plan.Creates) != 1 || plan.Creates[0].Config.Delta != 4 {
+		t.Errorf("creates = %+v, want stage after the last field", plan.Creates)
+	}
+}
+
+func TestPlanNewFieldNeedsType(t *testing.T) {
+	app := schemaApp()
+	spec := podio.NewAppSpec(app)
+	spec.Fields = append(spec.Fields, podio.FieldSpec{ExternalID: "stage", Config: podio.FieldConfig{Label: "Stage"}})
+
+	if plan, err := podio.Plan(app, spec); err == nil {
+		t.Errorf("planned a new field without a type:\n%s", plan)
+	}
+
+	// Existing fields may leave their type out.
+	spec = podio.NewAppSpec(app)
+	spec.Fields[0].Type = ""
+	if _, err := podio.Plan(app, spec); err != nil {
+		t.Errorf("Plan of an existing field without a type: %v", err)
+	}
+}
+
+func TestPlanReorder(t *testing.T) {
+	app := schemaApp()
+	spec := podio.NewAppSpec(app)
+	spec.Fields[0], spec.Fields[1] = spec.Fields[1], spec.Fields[0]
+
+	plan, err := podio.Plan(app, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deltas := map[string]int{}
+	for _, update := range plan.Updates {
+		deltas[update.ExternalID] = update.Config.Delta
+	}
+	// Title already has delta 1, its position in the spec.
+	if len(deltas) != 1 || deltas["amount"] != 0 {
+		t.Errorf("updated deltas = %v, want amount moved to 0", deltas)
+	}
+}
+
+func TestPlanComparesResponsibleByUserID(t *testing.T) {
+	app := schemaApp()
+	app.Config.Tasks = []podio.AppTask{{
+		Text:        "Follow up",
+		Responsible: []podio.User{{UserID: 7, Name: "Ada", Avatar: 12, LastSeenOn: "2024-01-02 10:00:00"}},
+	}}
+
+	spec := podio.NewAppSpec(app)
+	if user := spec.Config.Tasks[0].Responsible[0]; user != (podio.User{UserID: 7}) {
+		t.Errorf("spec responsible = %+v, want only the user id", user)
+	}
+
+	app.Config.Tasks[0].Responsible[0].LastSeenOn = "2024-02-03 11:00:00"
+	plan, err := podio.Plan(app, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("plan after a responsible user was seen:\n%s", plan)
+	}
+
+	spec.Config.Tasks[0].Responsible[0].UserID = 8
+	if plan, _ := podio.Plan(app, spec); len(plan.Config) != 1 {
+		t.Errorf("config changes = %+v, want the responsible change", plan.Config)
+	}
+}
+
+func TestLoadAppSpecYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deals.yaml")
+	spec := `app_id: 1
+config:
+  name: Deals
+  item_name: Deal
+fields:
+  - external_id: title
+    type: text
+    config:
+      label: Title
+      required: true
+  - external_id: stage
+    type: category
+    config:
+      label: Stage
+      settings:
+        options:
+          - text: New
+`
+	if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := podio.LoadAppSpec(path)
+	if err != nil {
+		t.Fatalf("LoadAppSpec: %v", err)
+	}
+	if loaded.AppID != 1 || loaded.Config.ItemName != "Deal" || len(loaded.Fields) != 2 {
+		t.Fatalf("spec = %+v", loaded)
+	}
+	if field := loaded.Fields[0]; field.ExternalID != "title" || !field.Config.Required {
+		t.Errorf("title = %+v", field)
+	}
+	if settings, ok := loaded.Fields[1].Config.Settings.(map[string]interface{}); !ok || settings["options"] == nil {
+		t.Errorf("stage settings = %#v, want options", loaded.Fields[1].Config.Settings)
+	}
+}
@@ -1,12 +1,8 @@
 package podio
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
 	"strconv"
 )
 
@@ -28,14 +24,22 @@ type Space struct {
 }
 
 func (c *Client) GetSpace(spaceID string) (*Space, error) {
+	return c.GetSpaceContext(context.Background(), spaceID)
+}
+
+func (c *Client) GetSpaceContext(ctx context.Context, spaceID string) (*Space, error) {
 	space := &Space{}
-	err := c.get(fmt.Sprintf("/space/%s", spaceID), space)
+	err := c.get(ctx, fmt.Sprintf("/space/%s", spaceID), space)
 	return space, err
 }
 
 func (c *Client) GetSpaceByURL(url string) (*Space, error) {
+	return c.GetSpaceByURLContext(context.Background(), url)
+}
+
+func (c *Client) GetSpaceByURLContext(ctx context.Context, url string) (*Space, error) {
 	space := &Space{}
-	err := c.get(fmt.Sprintf("/space/url?url=%s", url), space)
+	err := c.get(ctx, fmt.Sprintf("/space/url?url=%s", url), space)
 	return space, err
 }
 
@@ -49,30 +53,20 @@ type CreateSpaceParams struct {
 }
 
 func (c *Client) CreateSpace(params CreateSpaceParams) (*Space, error) {
-	body := &bytes.Buffer{}
-	err := json.NewEncoder(body).Encode(params)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to create space request: %w", err)
-	}
-
-	resp, err := c.httpClient.Post("/space/", "application/json", body)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to create space: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		output, _ := ioutil.ReadAll(resp.Body)
-
-		return nil, fmt.Errorf("podio-go: failed to create space: status code %d; return payload: %s", resp.StatusCode, string(output))
-	}
+	return c.CreateSpaceContext(context.Background(), params)
+}
 
+func (c *Client) CreateSpaceContext(ctx context.Context, params CreateSpaceParams) (*Space, error) {
 	data := &struct {
 		ID  int    `json:"space_id"`
 		Url string `json:"url"`
 	}{}
-	json.NewDecoder(resp.Body).Decode(data)
+	err := c.post(ctx, "/space/", params, data)
+	if err != nil {
+		return nil, fmt.Errorf("podio-go: failed to create space: %w", err)
+	}
 
-	space, err := c.GetSpace(strconv.Itoa(data.ID))
+	space, err := c.GetSpaceContext(ctx, strconv.Itoa(data.ID))
 	if err != nil {
 		return nil, fmt.Errorf("podio-go: failed to get space after creation: %w", err)
 	}
@@ -81,43 +75,40 @@ func (c *Client) CreateSpace(params CreateSpaceParams) (*Space, error) {
 }
 
 func (c *Client) DeleteSpace(spaceID string) error {
-	return c.delete(fmt.Sprintf("/space/%s", spaceID))
+	return c.DeleteSpaceContext(context.Background(), spaceID)
 }
 
-func (c *Client) UpdateSpace(spaceID string, params CreateSpaceParams) (*Space, error) {
-	body := &bytes.Buffer{}
-	err := json.NewEncoder(body).Encode(params)
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: failed to update space request: %w", err)
-	}
+func (c *Client) DeleteSpaceContext(ctx context.Context, spaceID string) error {
+	return c.delete(ctx, fmt.Sprintf("/space/%s", spaceID))
+}
 
-	url, err := url.Parse(fmt.Sprintf("/space/%s", spaceID))
-	if err != nil {
-		return nil, fmt.Errorf("podio-go: update space failed to create url: %w", err)
-	}
-	req := &http.Request{
-		Method: "PUT",
-		URL:    url,
-		Body:   ioutil.NopCloser(body),
-	}
+func (c *Client) UpdateSpace(spaceID string, params CreateSpaceParams) (*Space, error) {
+	return c.UpdateSpaceContext(context.Background(), spaceID, params)
+}
 
-	resp, err := c.httpClient.Do(req)
+func (c *Client) UpdateSpaceContext(ctx context.Context, spaceID string, params CreateSpaceParams) (*Space, error) {
+	err := c.put(ctx, fmt.Sprintf("/space/%s", spaceID), params, nil)
 	if err != nil {
-		return nil, fmt.Errorf("podio-go: update space failed to send request: %w", err)
+		return nil, fmt.Errorf("podio-go: update space failed: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusNoContent {
-		output, _ := ioutil.ReadAll(resp.Body)
-		fmt.Println(string(output))
-		return nil, fmt.Errorf("podio-go: update space failed: status code %d", resp.StatusCode)
-	}
-
-	space, err := c.GetSpace(spaceID)
+	space, err := c.GetSpaceContext(ctx, spaceID)
 	return space, err
 }
 
 func (c *Client) GetWorkSpaces(orgID string) (*[]Space, error) {
-	orgs := &[]Space{}
-	err := c.get(fmt.Sprintf("/space/org/%s/", orgID), orgs)
-	return orgs, err
+	return c.GetWorkSpacesContext(context.Background(), orgID)
+}
+
+func (c *Client) GetWorkSpacesContext(ctx context.Context, orgID string) (*[]Space, error) {
+	return collect(c.ListWorkSpacesContext(ctx, orgID))
+}
+
+// ListWorkSpaces returns an iterator over the spaces of an organization.
+func (c *Client) ListWorkSpaces(orgID string) *Iterator[Space] {
+	return c.ListWorkSpacesContext(context.Background(), orgID)
+}
+
+func (c *Client) ListWorkSpacesContext(ctx context.Context, orgID string) *Iterator[Space] {
+	return NewIterator(ctx, 0, unpaginated[Space](c, fmt.Sprintf("/space/org/%s/", orgID)))
 }
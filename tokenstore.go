@@ -0,0 +1,226 @@
+package podio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Token is an OAuth token held by a Client.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+}
+
+func newToken(oauth *oAuth2Token) *Token {
+	token := &Token{
+		AccessToken:  oauth.AccessToken,
+		RefreshToken: oauth.RefreshToken,
+	}
+
+	if oauth.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(oauth.ExpiresIn) * time.Second)
+	}
+
+	return token
+}
+
+// expiresWithin reports whether the token expires within d. Tokens without
+// an expiry never do.
+func (t *Token) expiresWithin(d time.Duration) bool {
+	return !t.ExpiresAt.IsZero() && time.Until(t.ExpiresAt) < d
+}
+
+// TokenStore persists tokens for a Client. The client saves a token whenever
+// it authenticates or refreshes, and loads it before refreshing in case
+// another process sharing the store already did.
+type TokenStore interface {
+	// Load returns the stored token, or nil if there is none.
+	Load() (*Token, error)
+	// Save replaces the stored token.
+	Save(token *Token) error
+}
+
+// LockingTokenStore is a TokenStore that can be shared between processes.
+// The client holds its lock from loading the stored token until it has saved
+// the refreshed one, so only one process uses a refresh token: Podio
+// invalidates a refresh token once used.
+type LockingTokenStore interface {
+	TokenStore
+	// Lock blocks until the store is locked or ctx is done, and returns a
+	// function that releases the lock.
+	Lock(ctx context.Context) (unlock func(), err error)
+}
+
+// LoadToken authenticates the client with the token from its TokenStore.
+// It reports whether a token was found.
+func (c *Client) LoadToken() (bool, error) {
+	if c.options.TokenStore == nil {
+		return false, nil
+	}
+
+	token, err := c.options.TokenStore.Load()
+	if err != nil {
+		return false, fmt.Errorf("podio-go: failed to load token: %w", err)
+	}
+
+	if token == nil {
+		return false, nil
+	}
+
+	c.auth.mu.Lock()
+	c.auth.token = token
+	c.auth.mu.Unlock()
+
+	return true, nil
+}
+
+// RefreshToken refreshes the client's token now, rather than when it is
+// about to expire. If another client sharing the TokenStore already
+// refreshed, its token is used instead.
+func (c *Client) RefreshToken() error {
+	return c.RefreshTokenContext(context.Background())
+}
+
+func (c *Client) RefreshTokenContext(ctx context.Context) error {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+
+	if c.auth.token == nil {
+		return fmt.Errorf("podio-go: not authenticated")
+	}
+
+	return c.auth.refresh(ctx)
+}
+
+// Token returns a copy of the client's current token, or nil if the client
+// is not authenticated.
+func (c *Client) Token() *Token {
+	return c.auth.currentToken()
+}
+
+// FileTokenStore stores a token as JSON in a file. Saves replace the file
+// atomically, so a reader never sees a partial token. It is a
+// LockingTokenStore, locking Path with ".lock" appended, so several
+// processes can share the file.
+type FileTokenStore struct {
+	Path string
+}
+
+// lockRetryInterval is how often Lock retries a lock held by another process.
+const lockRetryInterval = 50 * time.Millisecond
+
+// NewFileTokenStore returns a token store backed by the file at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{Path: path}
+}
+
+func (s *FileTokenStore) Load() (*Token, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("podio-go: failed to decode token file %s: %w", s.Path, err)
+	}
+
+	return token, nil
+}
+
+func (s *FileTokenStore) Save(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Lock locks the token file against other processes, and other
+// FileTokenStores in this one, until unlock is called.
+func (s *FileTokenStore) Lock(ctx context.Context) (func(), error) {
+	path := s.Path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("podio-go: failed to lock token file %s: %w", s.Path, err)
+	}
+
+	for {
+		unlock, err := tryLockFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("podio-go: failed to lock token file %s: %w", s.Path, err)
+		}
+		if unlock != nil {
+			return unlock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// MemoryTokenStore keeps a token in memory, e.g. to share one session
+// between several clients in the same process.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewMemoryTokenStore returns an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Load() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, nil
+	}
+
+	token := *s.token
+	return &token, nil
+}
+
+func (s *MemoryTokenStore) Save(token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *token
+	s.token = &saved
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package podio
+
+import (
+	"os"
+	"time"
+)
+
+// staleLockAge is how old a lock file must be before it is taken to be left
+// behind by a process that died while holding it.
+const staleLockAge = time.Minute
+
+// tryLockFile locks by creating the file at path, which fails while another
+// process holds the lock. It returns a nil unlock if the file exists.
+func tryLockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		f.Close()
+		os.Remove(path)
+	}, nil
+}
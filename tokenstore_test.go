@@ -0,0 +1,115 @@
+package podio_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kayteh/podio-go"
+	"github.com/kayteh/podio-go/podiotest"
+)
+
+func TestFileTokenStoreLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	first, second := podio.NewFileTokenStore(path), podio.NewFileTokenStore(path)
+
+	unlock, err := first.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := second.Lock(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Lock of a locked store: err = %v, want it to wait until the deadline", err)
+	}
+
+	unlock()
+
+	unlock, err = second.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Lock after unlock: %v", err)
+	}
+	unlock()
+}
+
+func TestFileTokenStoreSharedRefresh(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	app := srv.AddApplication(podio.Application{Config: podio.AppConfig{Name: "Deals"}})
+	appID := strconv.Itoa(app.AppID)
+
+	// Each client has its own store on the same file, as separate processes
+	// would, and they all start from the same saved token.
+	path := filepath.Join(t.TempDir(), "token.json")
+	var clients []*podio.Client
+	for n := 0; n < 4; n++ {
+		clients = append(clients, srv.NewClient(podio.WithTokenStore(podio.NewFileTokenStore(path))))
+	}
+	for _, client := range clients {
+		if loaded, err := client.LoadToken(); !loaded || err != nil {
+			t.Fatalf("LoadToken = %v, %v", loaded, err)
+		}
+	}
+
+	srv.ExpireTokens()
+	before := len(srv.Requests())
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(clients))
+	for n, client := range clients {
+		wg.Add(1)
+		go func(n int, client *podio.Client) {
+			defer wg.Done()
+			_, errs[n] = client.GetApplication(appID)
+		}(n, client)
+	}
+	wg.Wait()
+
+	for n, err := range errs {
+		if err != nil {
+			t.Errorf("client %d: %v", n, err)
+		}
+	}
+
+	grants := 0
+	for _, r := range srv.Requests()[before:] {
+		if r.Method == http.MethodPost && r.Path == "/oauth/token" {
+			grants++
+		}
+	}
+	if grants != 1 {
+		t.Errorf("refresh grants = %d, want 1", grants)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	srv := podiotest.NewServer()
+	defer srv.Close()
+
+	client := srv.NewClient()
+	before := client.Token()
+
+	if err := client.RefreshToken(); err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if after := client.Token(); after.AccessToken == before.AccessToken {
+		t.Error("RefreshToken kept the access token")
+	}
+
+	// Podio invalidates a refresh token once used.
+	store := podio.NewMemoryTokenStore()
+	stale := srv.NewClient(podio.WithTokenStore(store))
+	store.Save(before)
+	if _, err := stale.LoadToken(); err != nil {
+		t.Fatal(err)
+	}
+	if err := stale.RefreshToken(); err == nil {
+		t.Error("RefreshToken with a used refresh token returned no error")
+	}
+}
@@ -0,0 +1,202 @@
+package podio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// WebhookEvent is an event posted by Podio to a hook's url.
+type WebhookEvent struct {
+	// Type is the event that occurred.
+	Type HookEvent
+	// HookID is the id of the hook the event was sent by.
+	HookID int
+	// ItemID and ItemRevisionID are set for item events, and ExternalID to
+	// the external id of the item for item.create and item.delete.
+	ItemID         int
+	ItemRevisionID int
+	ExternalID     string
+	// AppID is set for app events.
+	AppID int
+	// FieldID is set for field events.
+	FieldID int
+	// CommentID is set for comment events.
+	CommentID int
+	// FileID is set for file.change.
+	FileID int
+	// TaskID is set for task events.
+	TaskID int
+	// StatusID is set for status events.
+	StatusID int
+	// UserID is set for member events.
+	UserID int
+	// Form holds every value posted with the event.
+	Form url.Values
+
+	// Item is the item the event is about, fetched when the handler's
+	// FetchItem is set.
+	Item *Item
+	// Application is the app the event is about, fetched when the handler's
+	// FetchApplication is set.
+	Application *Application
+}
+
+// WebhookFunc handles an event. Returning an error makes the handler
+// respond with 500.
+type WebhookFunc func(ctx context.Context, event *WebhookEvent) error
+
+// WebhookHandler receives the events of hooks. It completes the hook.verify
+// handshake and dispatches every other event to the callbacks registered for
+// its type with On. Events without a callback are acknowledged and dropped.
+type WebhookHandler struct {
+	// Client is used to validate hook verifications and fetch items and apps.
+	Client *Client
+	// FetchItem fetches the item of item events before calling back. Deleted
+	// items can't be fetched, so item.delete events are passed as is.
+	FetchItem bool
+	// FetchApplication fetches the app of app and field events before
+	// calling back.
+	FetchApplication bool
+	// OnError is called when an event can't be handled.
+	OnError func(r *http.Request, err error)
+
+	callbacks map[HookEvent][]WebhookFunc
+}
+
+// NewWebhookHandler returns a handler using client to validate hooks.
+func NewWebhookHandler(client *Client) *WebhookHandler {
+	return &WebhookHandler{Client: client}
+}
+
+// On registers fn to be called for events of the given type. Callbacks must
+// be registered before the handler starts serving.
+func (h *WebhookHandler) On(event HookEvent, fn WebhookFunc) {
+	if h.callbacks == nil {
+		h.callbacks = map[HookEvent][]WebhookFunc{}
+	}
+
+	h.callbacks[event] = append(h.callbacks[event], fn)
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.fail(w, r, http.StatusBadRequest, fmt.Errorf("podio-go: invalid hook event: %w", err))
+		return
+	}
+
+	event, err := parseWebhookEvent(r.PostForm)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if event.Type == HookEventVerify {
+		err := h.Client.ValidateHookVerificationContext(r.Context(), strconv.Itoa(event.HookID), event.Form.Get("code"))
+		if err != nil {
+			h.fail(w, r, http.StatusBadGateway, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	callbacks := h.callbacks[event.Type]
+	if len(callbacks) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.fetch(r.Context(), event); err != nil {
+		h.fail(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	for _, fn := range callbacks {
+		if err := fn(r.Context(), event); err != nil {
+			h.fail(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// fetch fills in the item or app of event, as configured.
+func (h *WebhookHandler) fetch(ctx context.Context, event *WebhookEvent) error {
+	var err error
+
+	if h.FetchItem && event.ItemID != 0 && event.Type != HookEventItemDelete {
+		event.Item, err = h.Client.GetItemContext(ctx, strconv.Itoa(event.ItemID))
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to fetch item %d: %w", event.ItemID, err)
+		}
+	}
+
+	if h.FetchApplication && event.AppID != 0 && event.Type != HookEventAppDelete {
+		event.Application, err = h.Client.GetApplicationContext(ctx, strconv.Itoa(event.AppID))
+		if err != nil {
+			return fmt.Errorf("podio-go: failed to fetch app %d: %w", event.AppID, err)
+		}
+	}
+
+	return nil
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+// parseWebhookEvent decodes the form posted with an event.
+func parseWebhookEvent(form url.Values) (*WebhookEvent, error) {
+	event := &WebhookEvent{
+		Type:       HookEvent(form.Get("type")),
+		ExternalID: form.Get("external_id"),
+		Form:       form,
+	}
+
+	if event.Type == "" {
+		return nil, fmt.Errorf("podio-go: invalid hook event: missing type")
+	}
+
+	ids := map[string]*int{
+		"hook_id":          &event.HookID,
+		"item_id":          &event.ItemID,
+		"item_revision_id": &event.ItemRevisionID,
+		"app_id":           &event.AppID,
+		"field_id":         &event.FieldID,
+		"comment_id":       &event.CommentID,
+		"file_id":          &event.FileID,
+		"task_id":          &event.TaskID,
+		"status_id":        &event.StatusID,
+		"user_id":          &event.UserID,
+	}
+
+	for key, id := range ids {
+		value := form.Get(key)
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("podio-go: invalid hook event: %s %q is not a number", key, value)
+		}
+		*id = n
+	}
+
+	return event, nil
+}
@@ -0,0 +1,219 @@
+package podio_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kayteh/podio-go"
+)
+
+// hookAPI fakes the endpoints the webhook handler calls.
+type hookAPI struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	validated map[string]string
+	fetched   []string
+}
+
+func newHookAPI() *hookAPI {
+	api := &hookAPI{validated: map[string]string{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	return api
+}
+
+func (api *hookAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	w.Header().Set("content-type", "application/json")
+
+	switch {
+	case r.URL.Path == "/oauth/token":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access",
+			"refresh_token": "refresh",
+			"expires_in":    28800,
+		})
+	case strings.HasPrefix(r.URL.Path, "/hook/") && strings.HasSuffix(r.URL.Path, "/verify/validate"):
+		body := struct {
+			Code string `json:"code"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		api.validated[strings.Split(r.URL.Path, "/")[2]] = body.Code
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasPrefix(r.URL.Path, "/item/"):
+		id := strings.TrimPrefix(r.URL.Path, "/item/")
+		api.fetched = append(api.fetched, id)
+		fmt.Fprintf(w, `{"item_id": %s, "title": "Deal"}`, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "not_found", "error_description": "Object not found"}`)
+	}
+}
+
+func newHookHandler(t *testing.T, api *hookAPI) *podio.WebhookHandler {
+	t.Helper()
+
+	client, err := podio.NewClientWithOptions(
+		podio.WithCredentials("client", "secret"),
+		podio.WithAPIURL(api.URL),
+		podio.WithAuthURL(api.URL),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AuthenticateWithCredentials("user", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	return podio.NewWebhookHandler(client)
+}
+
+func postEvent(handler http.Handler, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/hooks", strings.NewReader(form.Encode()))
+	req.Header.Set("content-type", "application/x-www-form-urlencoded")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebhookVerify(t *testing.T) {
+	api := newHookAPI()
+	defer api.Close()
+
+	handler := newHookHandler(t, api)
+	handler.On(podio.HookEventVerify, func(ctx context.Context, event *podio.WebhookEvent) error {
+		t.Error("hook.verify was dispatched to a callback")
+		return nil
+	})
+
+	rec := postEvent(handler, url.Values{"type": {"hook.verify"}, "hook_id": {"42"}, "code": {"abc123"}})
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body)
+	}
+	if code := api.validated["42"]; code != "abc123" {
+		t.Errorf("validated code = %q, want abc123", code)
+	}
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	api := newHookAPI()
+	defer api.Close()
+
+	handler := newHookHandler(t, api)
+	var created, updated []*podio.WebhookEvent
+	handler.On(podio.HookEventItemCreate, func(ctx context.Context, event *podio.WebhookEvent) error {
+		created = append(created, event)
+		return nil
+	})
+	handler.On(podio.HookEventItemUpdate, func(ctx context.Context, event *podio.WebhookEvent) error {
+		updated = append(updated, event)
+		return nil
+	})
+
+	for _, form := range []url.Values{
+		{"type": {"item.create"}, "hook_id": {"1"}, "item_id": {"10"}, "external_id": {"deal-10"}},
+		{"type": {"item.update"}, "hook_id": {"1"}, "item_id": {"11"}, "item_revision_id": {"3"}},
+		{"type": {"comment.create"}, "hook_id": {"1"}, "comment_id": {"12"}},
+	} {
+		if rec := postEvent(handler, form); rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, body %q", form.Get("type"), rec.Code, rec.Body)
+		}
+	}
+
+	if len(created) != 1 || created[0].ItemID != 10 || created[0].ExternalID != "deal-10" {
+		t.Errorf("item.create events = %+v", created)
+	}
+	if len(updated) != 1 || updated[0].ItemID != 11 || updated[0].ItemRevisionID != 3 {
+		t.Errorf("item.update events = %+v", updated)
+	}
+}
+
+func TestWebhookCallbackError(t *testing.T) {
+	api := newHookAPI()
+	defer api.Close()
+
+	handler := newHookHandler(t, api)
+	handler.On(podio.HookEventItemCreate, func(ctx context.Context, event *podio.WebhookEvent) error {
+		return fmt.Errorf("failed")
+	})
+
+	if rec := postEvent(handler, url.Values{"type": {"item.create"}, "item_id": {"10"}}); rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+}
+
+func TestWebhookFetchItem(t *testing.T) {
+	api := newHookAPI()
+	defer api.Close()
+
+	handler := newHookHandler(t, api)
+	handler.FetchItem = true
+
+	items := map[podio.HookEvent]*podio.Item{}
+	for _, event := range []podio.HookEvent{podio.HookEventItemUpdate, podio.HookEventItemDelete} {
+		handler.On(event, func(ctx context.Context, event *podio.WebhookEvent) error {
+			items[event.Type] = event.Item
+			return nil
+		})
+	}
+
+	postEvent(handler, url.Values{"type": {"item.update"}, "item_id": {"10"}})
+	postEvent(handler, url.Values{"type": {"item.delete"}, "item_id": {"11"}})
+
+	if item := items[podio.HookEventItemUpdate]; item == nil || item.ItemID != 10 {
+		t.Errorf("item.update item = %+v, want item 10", item)
+	}
+	if item, ok := items[podio.HookEventItemDelete]; !ok || item != nil {
+		t.Errorf("item.delete item = %+v, want the callback without an item", item)
+	}
+	if len(api.fetched) != 1 || api.fetched[0] != "10" {
+		t.Errorf("fetched items = %v, want only 10", api.fetched)
+	}
+}
+
+func TestWebhookInvalidEvent(t *testing.T) {
+	api := newHookAPI()
+	defer api.Close()
+
+	handler := newHookHandler(t, api)
+	var failed error
+	handler.OnError = func(r *http.Request, err error) {
+		failed = err
+	}
+	handler.On(podio.HookEventItemCreate, func(ctx context.Context, event *podio.WebhookEvent) error {
+		t.Error("an invalid event was dispatched")
+		return nil
+	})
+
+	for _, form := range []url.Values{
+		{"type": {"item.create"}, "item_id": {"abc"}},
+		{"type": {"item.create"}, "hook_id": {"1.5"}},
+		{"item_id": {"10"}},
+	} {
+		failed = nil
+		if rec := postEvent(handler, form); rec.Code != http.StatusBadRequest {
+			t.Errorf("%v: status = %d, want 400", form, rec.Code)
+		}
+		if failed == nil {
+			t.Errorf("%v: OnError was not called", form)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hooks", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rec.Code)
+	}
+}